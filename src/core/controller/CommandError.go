@@ -0,0 +1,44 @@
+//
+//  CommandError.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+)
+
+const COMMAND_ERROR = "CommandError" // sent when a command fails and no error handler is set, the body is a *CommandError
+
+var ErrDuplicate = errors.New("duplicate command execution") // an IIdempotentCommand execution was skipped as a duplicate
+
+/*
+CommandError Describes an ICommand execution that failed.
+
+Passed to the error handler of the Controller, or sent
+as the body of a COMMAND_ERROR notification.
+*/
+type CommandError struct {
+	Notification interfaces.INotification // the INotification the ICommand was executed for
+	Command      interfaces.ICommand      // the ICommand instance, nil if it was never created
+	Err          error                    // why the execution failed
+}
+
+/*
+Error Get the string representation of the CommandError.
+*/
+func (self *CommandError) Error() string {
+	return "command for " + self.Notification.Name() + " failed: " + self.Err.Error()
+}
+
+/*
+Unwrap Get the underlying error, for use with errors.Is and errors.As.
+*/
+func (self *CommandError) Unwrap() error {
+	return self.Err
+}
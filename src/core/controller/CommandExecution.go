@@ -0,0 +1,31 @@
+//
+//  CommandExecution.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"time"
+)
+
+/*
+CommandExecution Describes an execution of an ICommand, passed to the hooks of the Controller.
+
+The same *CommandExecution is passed to the before and the
+after hooks of an execution, so they may be correlated.
+Elapsed, Err and Panic are only set for the after hooks.
+*/
+type CommandExecution struct {
+	Notification interfaces.INotification // the INotification the ICommand is executed for
+	Command      interfaces.ICommand      // the ICommand instance
+	Attempt      int                      // the number of the attempt, starting at 1
+	Started      time.Time                // when Execute was called
+	Elapsed      time.Duration            // how long Execute took
+	Err          error                    // the error the ICommand failed with, if any
+	Panic        interface{}              // the value the ICommand panicked with, if any
+}
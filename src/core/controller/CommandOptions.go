@@ -0,0 +1,120 @@
+//
+//  CommandOptions.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"time"
+)
+
+/*
+WithTimeout Set a deadline for each execution of the ICommand,
+including any retries.
+
+The context.Context passed to an IContextCommand is cancelled
+when the deadline passes. If the ICommand has not returned by
+then, the Controller stops waiting for it and reports a
+CommandError wrapping context.DeadlineExceeded.
+
+- parameter timeout: the deadline for each execution, 0 for none
+*/
+func WithTimeout(timeout time.Duration) interfaces.CommandOption {
+	return func(options *interfaces.CommandOptions) {
+		options.Timeout = timeout
+	}
+}
+
+/*
+WithRetry Retry failed executions of the ICommand.
+
+An execution fails when an IContextCommand reports an error.
+Each attempt uses a new ICommand instance. The timeout of the
+mapping, if any, bounds all attempts together, and the delay
+between attempts ends early when it passes.
+
+- parameter policy: the IRetryPolicy deciding whether and when to retry
+*/
+func WithRetry(policy interfaces.IRetryPolicy) interfaces.CommandOption {
+	return func(options *interfaces.CommandOptions) {
+		options.Retry = policy
+	}
+}
+
+/*
+WithSerialKey Execute the ICommand in serial lanes.
+
+The key func is called with each INotification to choose
+a lane. Executions in the same lane, across every mapping
+of the Controller, run one at a time in the order they were
+dispatched, while different lanes run in parallel:
+
+	controller.WithSerialKey(func(notification interfaces.INotification) string {
+	  return notification.Body().(*Order).ID
+	})
+
+Executions in a lane are asynchronous: ExecuteCommand queues
+them and returns. A lane is removed once its queue is empty.
+An empty key executes immediately on the calling goroutine.
+
+- parameter key: the func returning the lane of an INotification
+*/
+func WithSerialKey(key func(notification interfaces.INotification) string) interfaces.CommandOption {
+	return func(options *interfaces.CommandOptions) {
+		options.SerialKey = key
+	}
+}
+
+/*
+WithRateLimit Limit how often executions of the ICommand start,
+using a token bucket.
+
+- parameter rate: the executions started per second
+
+- parameter burst: the executions that may start at once, at least 1
+*/
+func WithRateLimit(rate float64, burst int) interfaces.CommandOption {
+	return func(options *interfaces.CommandOptions) {
+		options.RateLimit = rate
+		options.Burst = burst
+	}
+}
+
+/*
+WithMaxInFlight Limit how many executions of the ICommand run at once.
+
+An execution abandoned after its timeout still counts
+until its ICommand returns.
+
+- parameter max: the executions running at once
+*/
+func WithMaxInFlight(max int) interfaces.CommandOption {
+	return func(options *interfaces.CommandOptions) {
+		options.MaxInFlight = max
+	}
+}
+
+/*
+WithOverflow Set what happens to executions of the ICommand
+exceeding its rate limit or in-flight cap.
+
+* OVERFLOW_REJECT, the default, skips the execution and reports a CommandError wrapping ErrRateLimited or ErrTooManyInFlight.
+
+* OVERFLOW_QUEUE waits until the execution is allowed to start, or until the timeout of the mapping passes.
+The execution then waits and runs on its own goroutine, so ExecuteCommand returns without blocking the
+goroutine that sent the INotification, and queued executions may start in any order.
+
+* OVERFLOW_DROP silently skips the execution.
+
+- parameter policy: the OverflowPolicy
+*/
+func WithOverflow(policy interfaces.OverflowPolicy) interfaces.CommandOption {
+	return func(options *interfaces.CommandOptions) {
+		options.Overflow = policy
+	}
+}
@@ -9,10 +9,15 @@
 package controller
 
 import (
+	"context"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/history"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"sort"
 	"sync"
+	"time"
 )
 
 /*
@@ -30,18 +35,54 @@ responsibilities:
 
 * Calling the ICommand's execute method, passing in the INotification.
 
+* Recording each executed IUndoableCommand with the IHistory of its Core.
+
+* Enforcing the timeout of each mapping, and reporting ICommands that fail.
+
 Your application must register ICommands with the
 Controller.
 
 The simplest way is to subclass Facade,
 and use its initializeController method to add your
 registrations.
+
+ICommands may register, replace and remove mappings,
+including their own, while they execute. No lock is held
+while an ICommand executes, and these rules apply to a
+notification that is already being dispatched:
+
+* The mapping is resolved when the Controller's observer is notified. An ICommand that has started always runs to completion, even if its mapping is replaced or removed meanwhile.
+
+* A mapping replaced before the Controller's observer is notified handles the notification with its new ICommand.
+
+* A mapping removed before the Controller's observer is notified does not handle the notification.
+
+* A mapping registered for a notification name that had none when the dispatch began takes effect from the next notification.
 */
 type Controller struct {
-	Key             string                                // The Multiton Key for this Core
-	commandMap      map[string]func() interfaces.ICommand // Mapping of Notification names to funcs that returns ICommand Class instances
-	commandMapMutex sync.RWMutex                          // Mutex for commandMap
-	view            interfaces.IView                      // Local reference to View
+	Key             string                              // The Multiton Key for this Core
+	commandMap      map[string]*commandMapping          // Mapping of Notification names to ICommand mappings
+	commandMapMutex sync.RWMutex                        // Mutex for commandMap
+	view            interfaces.IView                    // Local reference to View
+	history         interfaces.IHistory                 // Local reference to History
+	errorHandler    func(commandError *CommandError)    // Called when an ICommand fails, nil to send COMMAND_ERROR
+	retryHandler    func(attempt *RetryAttempt)         // Called before a failed attempt is retried
+	injector        interfaces.IInjector                // Populates the dependencies of each ICommand, nil for none
+	beforeHooks     []func(execution *CommandExecution) // Called before each ICommand executes
+	afterHooks      []func(execution *CommandExecution) // Called after each ICommand executes
+	dedupeStore     interfaces.IDedupeStore             // Keys of IIdempotentCommand executions, created on first use
+	hooksMutex      sync.RWMutex                        // Mutex for the hooks
+	lanes           map[string]*lane                    // Serial lanes by key
+	lanesMutex      sync.Mutex                          // Mutex for lanes
+}
+
+/*
+commandMapping A func that returns ICommand instances, and the options it was registered with.
+*/
+type commandMapping struct {
+	factory func() interfaces.ICommand
+	options interfaces.CommandOptions
+	limiter *limiter // nil if the options set no limit
 }
 
 var instanceMap = map[string]interfaces.IController{} // The Multiton Controller instanceMap.
@@ -78,32 +119,534 @@ and override the InitializeController method in the
 following way:
 
 	func (self *MyController) InitializeController() {
-	  self.commandMap = map[string]func() interfaces.ICommand{}
+	  self.commandMap = map[string]*commandMapping{}
 	  self.view = MyView.GetInstance(self.Key, func() interfaces.IView { return &MyView{Key: self.Key} })
+	  self.history = history.GetInstance(self.Key, func() interfaces.IHistory { return &history.History{Key: self.Key} })
 	}
 */
 func (self *Controller) InitializeController() {
-	self.commandMap = map[string]func() interfaces.ICommand{}
+	self.commandMap = map[string]*commandMapping{}
 	self.view = view.GetInstance(self.Key, func() interfaces.IView { return &view.View{Key: self.Key} })
+	self.history = history.GetInstance(self.Key, func() interfaces.IHistory { return &history.History{Key: self.Key} })
 }
 
 /*
 ExecuteCommand If an ICommand has previously been registered
 to handle a the given INotification, then it is executed.
 
+If the ICommand is an IContextCommand, its context.Context
+is initialized before Execute, and is cancelled once the
+timeout of the mapping passes. An ICommand that fails is
+retried according to the IRetryPolicy of the mapping, and
+if it still fails, or does not return before the timeout,
+it is reported as a CommandError.
+
+If the ICommand is an IUndoableCommand, it is recorded
+with the IHistory once it has executed successfully, in
+the scope the IHistory had when the INotification was sent.
+
+If the mapping has a serial key, the execution is queued
+in its lane instead, and ExecuteCommand returns immediately.
+The executions of a lane run one at a time, in the order
+their INotifications were sent: a lane waits for an ICommand
+to return before starting the next one, even if it timed out.
+
+If the mapping has a rate limit or an in-flight cap, an
+execution exceeding it is handled by the overflow policy:
+rejected and reported as a CommandError, queued until it is
+allowed to start, or dropped. A queued execution waits on its
+own goroutine, in which case ExecuteCommand returns immediately,
+or in its lane if the mapping has a serial key.
+
+An IIdempotentCommand whose key was already claimed is
+skipped, and reported as a CommandError wrapping ErrDuplicate.
+
+The mapping is looked up before the ICommand executes, and
+no lock is held while it executes.
+
 - parameter note: an INotification
 */
 func (self *Controller) ExecuteCommand(notification interfaces.INotification) {
 	self.commandMapMutex.RLock()
-	defer self.commandMapMutex.RUnlock()
+	var mapping = self.commandMap[notification.Name()]
+	self.commandMapMutex.RUnlock()
+
+	if mapping == nil {
+		return
+	}
+
+	var record = self.track()
+	if mapping.options.SerialKey != nil {
+		if key := mapping.options.SerialKey(notification); key != "" {
+			self.enqueue(key, func() { self.executeQueued(mapping, notification, record) })
+			return
+		}
+	}
+	self.executeMapping(mapping, notification, record, false)
+}
 
-	var factory = self.commandMap[notification.Name()]
-	if factory == nil {
+/*
+track Capture the IHistory scope of an execution about to start.
+
+- returns: the func to call once the execution is over, with the IUndoableCommand to record, or nil.
+*/
+func (self *Controller) track() func(command interfaces.IUndoableCommand, notification interfaces.INotification) {
+	if self.history == nil {
+		return func(command interfaces.IUndoableCommand, notification interfaces.INotification) {}
+	}
+	return self.history.Track()
+}
+
+/*
+executeQueued Execute the ICommand of a mapping in a serial lane,
+reporting a panic as a CommandError since no caller can recover it.
+*/
+func (self *Controller) executeQueued(mapping *commandMapping, notification interfaces.INotification, record func(command interfaces.IUndoableCommand, notification interfaces.INotification)) {
+	defer func() {
+		if r := recover(); r != nil {
+			self.reportError(&CommandError{Notification: notification, Err: fmt.Errorf("panic: %v", r)})
+		}
+	}()
+
+	self.executeMapping(mapping, notification, record, true)
+}
+
+/*
+executeMapping Admit an execution under the limits of a mapping,
+execute its ICommand, then report its failure or record it with the IHistory.
+
+An execution queued by OVERFLOW_QUEUE waits and executes on its own
+goroutine, unless it runs in a lane, which waits for it to be admitted.
+
+- parameter inLane: whether the execution runs in a serial lane
+*/
+func (self *Controller) executeMapping(mapping *commandMapping, notification interfaces.INotification, record func(command interfaces.IUndoableCommand, notification interfaces.INotification), inLane bool) {
+	var ctx, cancel = context.Background(), context.CancelFunc(func() {})
+	if mapping.options.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, mapping.options.Timeout)
+	}
+
+	var release = func() {}
+	if mapping.limiter != nil {
+		var err error
+		release, err = mapping.limiter.acquire(ctx, inLane)
+		if err == errMustWait {
+			go self.executeWaiting(ctx, cancel, mapping, notification, record)
+			return
+		}
+		if err != nil {
+			cancel()
+			record(nil, notification)
+			self.reportAdmission(notification, err)
+			return
+		}
+	}
+
+	defer cancel()
+	self.executeAdmitted(ctx, mapping, notification, release, record, inLane)
+}
+
+/*
+executeWaiting Wait on its own goroutine for an execution queued
+by OVERFLOW_QUEUE to be admitted, then execute it, reporting a
+panic as a CommandError since no caller can recover it.
+
+The goroutine that sent the INotification does not wait, so an
+ICommand may send the INotification of its own mapping without
+waiting for the in-flight slot it holds.
+*/
+func (self *Controller) executeWaiting(ctx context.Context, cancel context.CancelFunc, mapping *commandMapping, notification interfaces.INotification, record func(command interfaces.IUndoableCommand, notification interfaces.INotification)) {
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			self.reportError(&CommandError{Notification: notification, Err: fmt.Errorf("panic: %v", r)})
+		}
+	}()
+
+	release, err := mapping.limiter.acquire(ctx, true)
+	if err != nil {
+		record(nil, notification)
+		self.reportAdmission(notification, err)
 		return
 	}
-	commandInstance := factory()
-	commandInstance.InitializeNotifier(self.Key)
-	commandInstance.Execute(notification)
+	self.executeAdmitted(ctx, mapping, notification, release, record, false)
+}
+
+/*
+reportAdmission Report an execution that was not admitted, unless it was dropped.
+*/
+func (self *Controller) reportAdmission(notification interfaces.INotification, err error) {
+	if err != errDropped {
+		self.reportError(&CommandError{Notification: notification, Err: err})
+	}
+}
+
+/*
+executeAdmitted Execute the ICommand of an admitted execution,
+then report its failure or record it with the IHistory.
+
+The record func is called even if the ICommand panics.
+*/
+func (self *Controller) executeAdmitted(ctx context.Context, mapping *commandMapping, notification interfaces.INotification, release func(), record func(command interfaces.IUndoableCommand, notification interfaces.INotification), inLane bool) {
+	var recorded interfaces.IUndoableCommand
+	defer func() {
+		record(recorded, notification)
+	}()
+
+	commandInstance, err := self.execute(ctx, mapping, notification, release, inLane)
+	if err != nil {
+		self.reportError(&CommandError{Notification: notification, Command: commandInstance, Err: err})
+		return
+	}
+
+	if undoable, ok := commandInstance.(interfaces.IUndoableCommand); ok {
+		recorded = undoable
+	}
+}
+
+/*
+execute Execute the ICommand of a mapping within a context.Context.
+
+When the mapping has a timeout, the ICommand runs on its
+own goroutine so the Controller can stop waiting for it once
+the context.Context is done. A panic is still raised on the
+calling goroutine if the ICommand panics before its deadline.
+
+The release func is called once the ICommand has returned,
+even if the Controller stopped waiting for it. In a lane, the
+Controller still waits for the ICommand to return after the
+deadline, so the next execution of the lane cannot overlap it,
+and the timeout is reported once it has returned.
+
+- parameter inLane: whether the execution runs in a serial lane
+
+- returns: the ICommand instance of the last attempt, and the error it failed with, if any.
+*/
+func (self *Controller) execute(ctx context.Context, mapping *commandMapping, notification interfaces.INotification, release func(), inLane bool) (interfaces.ICommand, error) {
+	if mapping.options.Timeout <= 0 {
+		defer release()
+		return self.executeAttempts(ctx, mapping, notification, func(command interfaces.ICommand) {})
+	}
+
+	// the instance of the current attempt, reported if the Controller stops waiting for it
+	var attemptMutex sync.Mutex
+	var attempt interfaces.ICommand
+
+	type result struct {
+		command interfaces.ICommand
+		err     error
+		panic   interface{}
+	}
+	var done = make(chan *result, 1)
+	go func() {
+		var r = &result{}
+		defer func() {
+			release()
+			r.panic = recover()
+			done <- r
+		}()
+		r.command, r.err = self.executeAttempts(ctx, mapping, notification, func(command interfaces.ICommand) {
+			attemptMutex.Lock()
+			defer attemptMutex.Unlock()
+			attempt = command
+		})
+	}()
+
+	select {
+	case r := <-done:
+		if r.panic != nil {
+			panic(r.panic)
+		}
+		return r.command, r.err
+	case <-ctx.Done():
+		if inLane {
+			<-done
+		}
+		attemptMutex.Lock()
+		defer attemptMutex.Unlock()
+		return attempt, ctx.Err()
+	}
+}
+
+/*
+executeAttempts Execute the ICommand of a mapping, retrying
+failed attempts according to its IRetryPolicy.
+
+- parameter started: called with the ICommand instance of each attempt once it is created
+
+- returns: the ICommand instance of the last attempt, and the error it failed with, if any.
+*/
+func (self *Controller) executeAttempts(ctx context.Context, mapping *commandMapping, notification interfaces.INotification, started func(command interfaces.ICommand)) (interfaces.ICommand, error) {
+	for attempt := 1; ; attempt++ {
+		commandInstance := mapping.factory()
+		commandInstance.InitializeNotifier(self.Key)
+		started(commandInstance)
+
+		if err := self.InjectCommand(commandInstance); err != nil {
+			return commandInstance, err
+		}
+
+		done, claimed := self.ClaimExecution(commandInstance, notification)
+		if claimed == false {
+			return nil, nil
+		}
+
+		var err = self.executeHooked(ctx, commandInstance, notification, attempt, done)
+		if err == nil || mapping.options.Retry == nil {
+			return commandInstance, err
+		}
+
+		delay, retry := mapping.options.Retry.NextDelay(attempt, err)
+		if retry == false || ctx.Err() != nil {
+			return commandInstance, err
+		}
+		self.reportRetry(&RetryAttempt{Notification: notification, Command: commandInstance, Attempt: attempt, Err: err, Delay: delay})
+
+		var timer = time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return commandInstance, err
+		}
+	}
+}
+
+/*
+executeHooked Execute an ICommand between the before and after hooks.
+
+The after hooks are called even if the ICommand panics,
+after which the panic resumes. The done func is called
+with the result before the after hooks.
+*/
+func (self *Controller) executeHooked(ctx context.Context, command interfaces.ICommand, notification interfaces.INotification, attempt int, done func(err error)) (err error) {
+	self.hooksMutex.RLock()
+	var before, after = self.beforeHooks, self.afterHooks
+	self.hooksMutex.RUnlock()
+
+	var execution = &CommandExecution{Notification: notification, Command: command, Attempt: attempt}
+	for _, hook := range before {
+		hook(execution)
+	}
+
+	execution.Started = time.Now()
+	defer func() {
+		execution.Elapsed = time.Since(execution.Started)
+		execution.Err = err
+		if execution.Panic = recover(); execution.Panic != nil {
+			done(fmt.Errorf("panic: %v", execution.Panic))
+			defer panic(execution.Panic)
+		} else {
+			done(err)
+		}
+		for _, hook := range after {
+			hook(execution)
+		}
+	}()
+
+	return executeCommand(ctx, command, notification)
+}
+
+/*
+executeCommand Execute an ICommand within a context.Context.
+
+- returns: the error the ICommand failed with, if it is an IContextCommand.
+*/
+func executeCommand(ctx context.Context, command interfaces.ICommand, notification interfaces.INotification) error {
+	if contextCommand, ok := command.(interfaces.IContextCommand); ok {
+		contextCommand.InitializeContext(ctx)
+		command.Execute(notification)
+		return contextCommand.Err()
+	}
+	command.Execute(notification)
+	return nil
+}
+
+/*
+SetErrorHandler Set the func called when an ICommand fails.
+
+By default, a COMMAND_ERROR notification is sent with
+the *CommandError as its body.
+
+- parameter handler: the func called with each CommandError, nil to restore the default
+*/
+func (self *Controller) SetErrorHandler(handler func(commandError *CommandError)) {
+	self.hooksMutex.Lock()
+	defer self.hooksMutex.Unlock()
+
+	self.errorHandler = handler
+}
+
+/*
+SetRetryHandler Set the func called before a failed attempt is retried.
+
+- parameter handler: the func called with each RetryAttempt, nil for none
+*/
+func (self *Controller) SetRetryHandler(handler func(attempt *RetryAttempt)) {
+	self.hooksMutex.Lock()
+	defer self.hooksMutex.Unlock()
+
+	self.retryHandler = handler
+}
+
+/*
+InjectCommand Populate the dependencies of an ICommand with the IInjector, if any.
+
+Called by the Controller before each execution, and by
+MacroCommand before each of its SubCommands.
+
+- parameter command: the ICommand about to be executed
+
+- returns: the error of the IInjector, nil if there is none.
+*/
+func (self *Controller) InjectCommand(command interfaces.ICommand) error {
+	self.hooksMutex.RLock()
+	var injector = self.injector
+	self.hooksMutex.RUnlock()
+
+	if injector == nil {
+		return nil
+	}
+	return injector.Inject(command)
+}
+
+/*
+AddBeforeHook Add a func called before each ICommand executes.
+
+Hooks are called on the goroutine executing the ICommand,
+once per attempt, in the order they were added, after the
+dependencies of the ICommand have been injected.
+
+- parameter hook: the func called with the CommandExecution
+*/
+func (self *Controller) AddBeforeHook(hook func(execution *CommandExecution)) {
+	self.hooksMutex.Lock()
+	defer self.hooksMutex.Unlock()
+
+	self.beforeHooks = append(self.beforeHooks[:len(self.beforeHooks):len(self.beforeHooks)], hook)
+}
+
+/*
+AddAfterHook Add a func called after each ICommand executes.
+
+Hooks are called on the goroutine executing the ICommand,
+once per attempt, in the order they were added, with
+the elapsed time and the error or panic of the ICommand.
+A panic resumes once every hook has been called.
+
+- parameter hook: the func called with the CommandExecution
+*/
+func (self *Controller) AddAfterHook(hook func(execution *CommandExecution)) {
+	self.hooksMutex.Lock()
+	defer self.hooksMutex.Unlock()
+
+	self.afterHooks = append(self.afterHooks[:len(self.afterHooks):len(self.afterHooks)], hook)
+}
+
+/*
+SetDedupeStore Set the IDedupeStore claiming the keys of IIdempotentCommand executions.
+
+By default, a MemoryDedupeStore with DEFAULT_DEDUPE_CAPACITY
+and DEFAULT_DEDUPE_TTL is created on first use.
+
+- parameter store: the IDedupeStore, nil to restore the default
+*/
+func (self *Controller) SetDedupeStore(store interfaces.IDedupeStore) {
+	self.hooksMutex.Lock()
+	defer self.hooksMutex.Unlock()
+
+	self.dedupeStore = store
+}
+
+/*
+ClaimExecution Claim the execution of an ICommand with the IDedupeStore.
+
+An ICommand that is not an IIdempotentCommand, or whose key
+is empty, is always claimed. Keys are scoped by the type of
+the ICommand. A duplicate is reported as a CommandError
+wrapping ErrDuplicate.
+
+Called by the Controller before each execution, and by
+MacroCommand before each of its SubCommands.
+
+- parameter command: the ICommand about to be executed
+
+- parameter notification: the INotification it is executed with
+
+- returns: the func to call with the error the execution failed with, which releases the key if not nil, and whether the execution was claimed. It may be called again with an error once the execution is undone, to release the key.
+*/
+func (self *Controller) ClaimExecution(command interfaces.ICommand, notification interfaces.INotification) (func(err error), bool) {
+	idempotent, ok := command.(interfaces.IIdempotentCommand)
+	if ok == false {
+		return func(err error) {}, true
+	}
+	var key = idempotent.IdempotencyKey(notification)
+	if key == "" {
+		return func(err error) {}, true
+	}
+	key = fmt.Sprintf("%T:%s", command, key)
+
+	self.hooksMutex.Lock()
+	if self.dedupeStore == nil {
+		self.dedupeStore = &MemoryDedupeStore{Capacity: DEFAULT_DEDUPE_CAPACITY, TTL: DEFAULT_DEDUPE_TTL}
+	}
+	var store = self.dedupeStore
+	self.hooksMutex.Unlock()
+
+	if store.Claim(key) == false {
+		self.reportError(&CommandError{Notification: notification, Command: command, Err: fmt.Errorf("%w: %s", ErrDuplicate, key)})
+		return nil, false
+	}
+	return func(err error) {
+		if err != nil {
+			store.Release(key)
+		}
+	}, true
+}
+
+/*
+SetInjector Set the IInjector populating the dependencies of
+each ICommand after InitializeNotifier and before Execute.
+
+An ICommand whose dependencies cannot be injected is not
+executed, nor retried, and the error is reported.
+
+- parameter injector: the IInjector, nil for none
+*/
+func (self *Controller) SetInjector(injector interfaces.IInjector) {
+	self.hooksMutex.Lock()
+	defer self.hooksMutex.Unlock()
+
+	self.injector = injector
+}
+
+/*
+reportRetry Pass a RetryAttempt to the retry handler, if any.
+*/
+func (self *Controller) reportRetry(attempt *RetryAttempt) {
+	self.hooksMutex.RLock()
+	var handler = self.retryHandler
+	self.hooksMutex.RUnlock()
+
+	if handler != nil {
+		handler(attempt)
+	}
+}
+
+/*
+reportError Pass a CommandError to the error handler,
+or send it as a COMMAND_ERROR notification.
+*/
+func (self *Controller) reportError(commandError *CommandError) {
+	self.hooksMutex.RLock()
+	var handler = self.errorHandler
+	self.hooksMutex.RUnlock()
+
+	if handler != nil {
+		handler(commandError)
+	} else if commandError.Notification.Name() != COMMAND_ERROR {
+		self.view.NotifyObservers(observer.NewNotification(COMMAND_ERROR, commandError, ""))
+	}
 }
 
 /*
@@ -112,7 +655,8 @@ for a particular INotification.
 
 If an ICommand has already been registered to
 handle INotifications with this name, it is no longer
-used, the new ICommand is used instead.
+used, the new ICommand is used instead. Executions
+that have already started are not affected.
 
 The Observer for the new ICommand is only created if this the
 first time an ICommand has been regisered for this Notification name.
@@ -120,15 +664,23 @@ first time an ICommand has been regisered for this Notification name.
 - parameter notificationName: the name of the INotification
 
 - parameter factory: reference that returns ICommand
+
+- parameter options: CommandOption funcs applied to the mapping (optional)
 */
-func (self *Controller) RegisterCommand(notificationName string, factory func() interfaces.ICommand) {
+func (self *Controller) RegisterCommand(notificationName string, factory func() interfaces.ICommand, options ...interfaces.CommandOption) {
+	var mapping = &commandMapping{factory: factory}
+	for _, option := range options {
+		option(&mapping.options)
+	}
+	mapping.limiter = newLimiter(mapping.options)
+
 	self.commandMapMutex.Lock()
 	defer self.commandMapMutex.Unlock()
 
 	if self.commandMap[notificationName] == nil {
 		self.view.RegisterObserver(notificationName, &observer.Observer{Notify: self.ExecuteCommand, Context: self})
 	}
-	self.commandMap[notificationName] = factory
+	self.commandMap[notificationName] = mapping
 }
 
 /*
@@ -145,9 +697,41 @@ func (self *Controller) HasCommand(notificationName string) bool {
 	return self.commandMap[notificationName] != nil
 }
 
+/*
+ListCommands List the Notification names Commands are registered for.
+
+- returns: the Notification names, sorted.
+*/
+func (self *Controller) ListCommands() []string {
+	self.commandMapMutex.RLock()
+	var names = make([]string, 0, len(self.commandMap))
+	for name := range self.commandMap {
+		names = append(names, name)
+	}
+	self.commandMapMutex.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+/*
+CommandCount Count the registered Commands.
+
+- returns: the number of Notification names Commands are registered for.
+*/
+func (self *Controller) CommandCount() int {
+	self.commandMapMutex.RLock()
+	defer self.commandMapMutex.RUnlock()
+
+	return len(self.commandMap)
+}
+
 /*
 RemoveCommand Remove a previously registered ICommand to INotification mapping.
 
+Executions that have already started are not affected,
+so an ICommand may safely remove its own mapping.
+
 - parameter notificationName: the name of the INotification to remove the ICommand mapping for
 */
 func (self *Controller) RemoveCommand(notificationName string) {
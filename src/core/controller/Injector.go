@@ -0,0 +1,138 @@
+//
+//  Injector.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/history"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/model"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"reflect"
+	"strings"
+)
+
+var ErrMissingDependency = errors.New("missing dependency") // a required dependency of an ICommand is not registered
+
+/*
+Injector An IInjector resolving dependencies from a Core.
+
+Set it on the Controller, and every ICommand it creates has its
+tagged fields populated after InitializeNotifier and before Execute:
+
+	type SaveUserCommand struct {
+	  command.SimpleCommand
+	  Users   *UserProxy         `inject:"proxy:UserProxy"`
+	  Form    *UserFormMediator  `inject:"mediator:UserFormMediator,optional"`
+	  History interfaces.IHistory `inject:"history"`
+	}
+
+	controller.SetInjector(&controller.Injector{Key: key})
+
+The tag is one of proxy:<name>, mediator:<name>, model, view,
+controller or history, optionally followed by ",optional" to
+leave the field unset rather than fail when the dependency is
+missing. Tagged fields must be exported, and the dependency
+assignable to them. Fields of embedded structs are populated too.
+
+When a required dependency is missing, the ICommand is not
+executed and the Controller reports an error wrapping ErrMissingDependency.
+*/
+type Injector struct {
+	Key string // The Multiton Key of the Core to resolve dependencies from
+}
+
+/*
+Inject Populate the tagged fields of a target.
+
+- parameter target: a pointer to the struct to populate
+
+- returns: an error naming the field if a dependency could not be resolved or assigned.
+*/
+func (self *Injector) Inject(target interface{}) error {
+	var value = reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return self.injectStruct(value.Elem())
+}
+
+/*
+injectStruct Populate the tagged fields of a struct value, and of its embedded structs.
+*/
+func (self *Injector) injectStruct(value reflect.Value) error {
+	var _type = value.Type()
+	for i := 0; i < _type.NumField(); i++ {
+		field := _type.Field(i)
+		tag, ok := field.Tag.Lookup("inject")
+		if ok == false {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && field.IsExported() {
+				if err := self.injectStruct(value.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		var where = _type.String() + "." + field.Name
+		if field.IsExported() == false {
+			return fmt.Errorf("cannot inject %q into unexported field %s", tag, where)
+		}
+
+		spec, optional := strings.CutSuffix(tag, ",optional")
+		dependency, err := self.resolve(spec)
+		if err != nil {
+			return fmt.Errorf("%s: %w", where, err)
+		}
+		if dependency == nil {
+			if optional {
+				continue
+			}
+			return fmt.Errorf("%w: %s for %s", ErrMissingDependency, spec, where)
+		}
+
+		var dependencyValue = reflect.ValueOf(dependency)
+		if dependencyValue.Type().AssignableTo(field.Type) == false {
+			return fmt.Errorf("cannot inject %s of type %s into %s of type %s", spec, dependencyValue.Type(), where, field.Type)
+		}
+		value.Field(i).Set(dependencyValue)
+	}
+	return nil
+}
+
+/*
+resolve Resolve the dependency described by a tag.
+
+- returns: the dependency, nil if it is not registered, or an error if the tag is invalid.
+*/
+func (self *Injector) resolve(spec string) (interface{}, error) {
+	kind, name, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "proxy":
+		if proxy := model.GetInstance(self.Key, func() interfaces.IModel { return &model.Model{Key: self.Key} }).RetrieveProxy(name); proxy != nil {
+			return proxy, nil
+		}
+	case "mediator":
+		if mediator := view.GetInstance(self.Key, func() interfaces.IView { return &view.View{Key: self.Key} }).RetrieveMediator(name); mediator != nil {
+			return mediator, nil
+		}
+	case "model":
+		return model.GetInstance(self.Key, func() interfaces.IModel { return &model.Model{Key: self.Key} }), nil
+	case "view":
+		return view.GetInstance(self.Key, func() interfaces.IView { return &view.View{Key: self.Key} }), nil
+	case "controller":
+		return GetInstance(self.Key, func() interfaces.IController { return &Controller{Key: self.Key} }), nil
+	case "history":
+		return history.GetInstance(self.Key, func() interfaces.IHistory { return &history.History{Key: self.Key} }), nil
+	default:
+		return nil, fmt.Errorf("unknown inject tag %q", spec)
+	}
+	return nil, nil
+}
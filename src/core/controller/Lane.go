@@ -0,0 +1,63 @@
+//
+//  Lane.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+/*
+lane A queue of executions that run one at a time, in order,
+on a goroutine that exits once the queue is empty.
+*/
+type lane struct {
+	queue []func() // Executions waiting to run
+}
+
+/*
+enqueue Queue an execution in the lane for a key,
+creating the lane and its goroutine if needed.
+
+- parameter key: the key of the lane
+
+- parameter execution: the func to run in the lane
+*/
+func (self *Controller) enqueue(key string, execution func()) {
+	self.lanesMutex.Lock()
+	defer self.lanesMutex.Unlock()
+
+	if self.lanes == nil {
+		self.lanes = map[string]*lane{}
+	}
+	if l := self.lanes[key]; l != nil {
+		l.queue = append(l.queue, execution)
+		return
+	}
+
+	var l = &lane{queue: []func(){execution}}
+	self.lanes[key] = l
+	go self.drain(key, l)
+}
+
+/*
+drain Run the executions of a lane until its queue is empty,
+then remove the lane.
+*/
+func (self *Controller) drain(key string, l *lane) {
+	for {
+		self.lanesMutex.Lock()
+		if len(l.queue) == 0 {
+			delete(self.lanes, key)
+			self.lanesMutex.Unlock()
+			return
+		}
+		var execution = l.queue[0]
+		l.queue[0] = nil
+		l.queue = l.queue[1:]
+		self.lanesMutex.Unlock()
+
+		execution()
+	}
+}
@@ -0,0 +1,154 @@
+//
+//  Limiter.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"sync"
+	"time"
+)
+
+var ErrRateLimited = errors.New("command rate limit exceeded")          // an execution was rejected by the RateLimit of its mapping
+var ErrTooManyInFlight = errors.New("command in-flight limit exceeded") // an execution was rejected by the MaxInFlight of its mapping
+var errDropped = errors.New("command dropped")                          // an execution was dropped, never reported
+var errMustWait = errors.New("command must wait")                       // an execution must wait to be admitted, never reported
+
+/*
+limiter A token bucket and an in-flight cap shared by the executions of a mapping.
+*/
+type limiter struct {
+	rate     float64                   // tokens added per second, 0 for no limit
+	burst    float64                   // capacity of the bucket
+	tokens   float64                   // tokens currently in the bucket
+	last     time.Time                 // when tokens was last updated
+	mutex    sync.Mutex                // Mutex for tokens and last
+	slots    chan struct{}             // one element per running execution, nil for no cap
+	overflow interfaces.OverflowPolicy // what happens to executions over the limits
+}
+
+/*
+newLimiter Create the limiter for a mapping, nil if its options set no limit.
+*/
+func newLimiter(options interfaces.CommandOptions) *limiter {
+	if options.RateLimit <= 0 && options.MaxInFlight <= 0 {
+		return nil
+	}
+
+	var l = &limiter{rate: options.RateLimit, burst: float64(options.Burst), overflow: options.Overflow, last: time.Now()}
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	l.tokens = l.burst
+	if options.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, options.MaxInFlight)
+	}
+	return l
+}
+
+/*
+acquire Admit an execution.
+
+If the overflow policy is OVERFLOW_QUEUE and the execution is
+not allowed yet, acquire waits until it is when wait is true,
+or returns errMustWait without taking anything otherwise.
+
+- returns: the func to call once the execution is done, or why it was not admitted.
+*/
+func (self *limiter) acquire(ctx context.Context, wait bool) (func(), error) {
+	var release = func() {}
+	if self.slots != nil {
+		if err := self.occupy(ctx, wait); err != nil {
+			return nil, err
+		}
+		release = func() { <-self.slots }
+	}
+
+	if err := self.take(ctx, wait); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+/*
+occupy Occupy an in-flight slot, waiting for one
+if the overflow policy is OVERFLOW_QUEUE and wait is true.
+*/
+func (self *limiter) occupy(ctx context.Context, wait bool) error {
+	select {
+	case self.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	switch self.overflow {
+	case interfaces.OVERFLOW_QUEUE:
+		if wait == false {
+			return errMustWait
+		}
+		select {
+		case self.slots <- struct{}{}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case interfaces.OVERFLOW_DROP:
+		return errDropped
+	default:
+		return ErrTooManyInFlight
+	}
+}
+
+/*
+take Take a token from the bucket, waiting for one
+if the overflow policy is OVERFLOW_QUEUE and wait is true.
+*/
+func (self *limiter) take(ctx context.Context, wait bool) error {
+	if self.rate <= 0 {
+		return nil
+	}
+
+	for {
+		self.mutex.Lock()
+		var now = time.Now()
+		self.tokens += now.Sub(self.last).Seconds() * self.rate
+		if self.tokens > self.burst {
+			self.tokens = self.burst
+		}
+		self.last = now
+
+		if self.tokens >= 1 {
+			self.tokens--
+			self.mutex.Unlock()
+			return nil
+		}
+		var delay = time.Duration((1 - self.tokens) / self.rate * float64(time.Second))
+		self.mutex.Unlock()
+
+		switch self.overflow {
+		case interfaces.OVERFLOW_QUEUE:
+			if wait == false {
+				return errMustWait
+			}
+			var timer = time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		case interfaces.OVERFLOW_DROP:
+			return errDropped
+		default:
+			return ErrRateLimited
+		}
+	}
+}
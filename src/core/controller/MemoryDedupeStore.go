@@ -0,0 +1,105 @@
+//
+//  MemoryDedupeStore.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const DEFAULT_DEDUPE_CAPACITY = 1024        // capacity of the IDedupeStore a Controller creates by default
+const DEFAULT_DEDUPE_TTL = 10 * time.Minute // TTL of the IDedupeStore a Controller creates by default
+
+/*
+MemoryDedupeStore An in-memory IDedupeStore.
+
+Keys expire once their TTL has passed, and the least recently
+claimed keys are evicted once the Capacity is exceeded.
+*/
+type MemoryDedupeStore struct {
+	Capacity int           // maximum number of keys kept, 0 for no limit
+	TTL      time.Duration // how long a key is kept, 0 for no expiry
+	entries  map[string]*list.Element
+	order    *list.List // claimed keys, most recent first
+	mutex    sync.Mutex // Mutex for entries and order
+}
+
+/*
+dedupeEntry A claimed key and when it expires.
+*/
+type dedupeEntry struct {
+	key     string
+	expires time.Time
+}
+
+/*
+Claim Claim a key, unless it is claimed and not expired.
+
+- parameter key: the key of an execution
+
+- returns: true if the key was claimed, false for a duplicate.
+*/
+func (self *MemoryDedupeStore) Claim(key string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.entries == nil {
+		self.entries = map[string]*list.Element{}
+		self.order = list.New()
+	}
+
+	var now = time.Now()
+	if element, ok := self.entries[key]; ok {
+		if self.expired(element.Value.(*dedupeEntry), now) == false {
+			return false
+		}
+		self.remove(element)
+	}
+
+	var e = &dedupeEntry{key: key}
+	if self.TTL > 0 {
+		e.expires = now.Add(self.TTL)
+	}
+	self.entries[key] = self.order.PushFront(e)
+
+	for self.Capacity > 0 && self.order.Len() > self.Capacity {
+		self.remove(self.order.Back())
+	}
+	return true
+}
+
+/*
+Release Release a claimed key.
+
+- parameter key: the key of an execution
+*/
+func (self *MemoryDedupeStore) Release(key string) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if element, ok := self.entries[key]; ok {
+		self.remove(element)
+	}
+}
+
+/*
+expired Check if an entry has expired.
+*/
+func (self *MemoryDedupeStore) expired(e *dedupeEntry, now time.Time) bool {
+	return e.expires.IsZero() == false && now.After(e.expires)
+}
+
+/*
+remove Remove an element. Must be called with the mutex held.
+*/
+func (self *MemoryDedupeStore) remove(element *list.Element) {
+	delete(self.entries, element.Value.(*dedupeEntry).key)
+	self.order.Remove(element)
+}
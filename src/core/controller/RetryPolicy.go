@@ -0,0 +1,94 @@
+//
+//  RetryPolicy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"math"
+	"math/rand"
+	"time"
+)
+
+/*
+RetryPolicy An IRetryPolicy with exponential backoff and jitter.
+
+The delay before attempt n+1 is InitialDelay * Multiplier^(n-1),
+capped at MaxDelay, then reduced by a random fraction of up
+to Jitter so that concurrent retries spread out:
+
+	controller.WithRetry(&controller.RetryPolicy{
+	  MaxAttempts:  5,
+	  InitialDelay: 100 * time.Millisecond,
+	  MaxDelay:     5 * time.Second,
+	  Multiplier:   2,
+	  Jitter:       0.5,
+	  Retryable:    func(err error) bool { return errors.Is(err, ErrUnavailable) },
+	})
+
+Errors caused by a done context.Context are never retried.
+*/
+type RetryPolicy struct {
+	MaxAttempts  int                  // maximum number of attempts, including the first one
+	InitialDelay time.Duration        // delay before the second attempt
+	MaxDelay     time.Duration        // upper bound of the delay, 0 for none
+	Multiplier   float64              // factor applied to the delay after each attempt, 1 if 0
+	Jitter       float64              // fraction of the delay randomly removed, from 0 to 1
+	Retryable    func(err error) bool // whether an error is transient, nil to retry every error
+}
+
+/*
+NextDelay Decide whether a failed attempt is retried.
+
+- parameter attempt: the number of the failed attempt, starting at 1
+
+- parameter err: the error the attempt failed with
+
+- returns: the delay before the next attempt, and whether there is one.
+*/
+func (self *RetryPolicy) NextDelay(attempt int, err error) (time.Duration, bool) {
+	if attempt >= self.MaxAttempts {
+		return 0, false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+	if self.Retryable != nil && self.Retryable(err) == false {
+		return 0, false
+	}
+
+	var multiplier = self.Multiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	var delay = float64(self.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if self.MaxDelay > 0 && delay > float64(self.MaxDelay) {
+		delay = float64(self.MaxDelay)
+	} else if delay > math.MaxInt64 {
+		delay = math.MaxInt64
+	}
+	if self.Jitter > 0 {
+		delay -= delay * self.Jitter * rand.Float64()
+	}
+	return time.Duration(delay), true
+}
+
+/*
+RetryAttempt Describes a failed attempt that is about to be retried.
+
+Passed to the retry handler of the Controller.
+*/
+type RetryAttempt struct {
+	Notification interfaces.INotification // the INotification the ICommand is executed for
+	Command      interfaces.ICommand      // the ICommand instance of the failed attempt
+	Attempt      int                      // the number of the failed attempt, starting at 1
+	Err          error                    // the error the attempt failed with
+	Delay        time.Duration            // the delay before the next attempt
+}
@@ -0,0 +1,447 @@
+//
+//  History.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"sync"
+)
+
+const UNDO = "HistoryUndo"               // send to undo the most recent step
+const REDO = "HistoryRedo"               // send to redo the most recently undone step
+const HISTORY_CHANGED = "HistoryChanged" // sent when undo or redo availability changes, the body is a *State
+
+/*
+State The body of a HISTORY_CHANGED notification.
+*/
+type State struct {
+	CanUndo bool // whether a step can be undone
+	CanRedo bool // whether a step can be redone
+}
+
+/*
+entry A recorded IUndoableCommand and the INotification it was executed with.
+*/
+type entry struct {
+	command      interfaces.IUndoableCommand
+	notification interfaces.INotification
+}
+
+/*
+History A Multiton IHistory implementation.
+
+The Controller records every IUndoableCommand it executes
+with the History of its Core. A recorded step is undone
+by calling Undo on its commands in reverse order, and redone
+by calling Execute on them again in their original order.
+
+The History registers itself as an IObserver with the View
+for the UNDO and REDO notifications, so any INotifier
+in the Core may undo or redo with:
+
+	self.SendNotification(history.UNDO, nil, "")
+
+Whenever the availability of undo or redo changes,
+a HISTORY_CHANGED notification is sent with a *State body.
+
+Groups and replays are core-wide, and an execution belongs to
+the group open, or the replay running, when its INotification
+is sent: an ICommand that runs later, on another goroutine, is
+recorded in the group even if it runs after EndGroup, and is not
+recorded if it was sent while a step was undone or redone. Commands sent meanwhile by other goroutines share the
+same scope.
+*/
+type History struct {
+	Key       string           // The Multiton Key for this Core
+	undoStack [][]entry        // Steps that can be undone, most recent last
+	redoStack [][]entry        // Steps that can be redone, most recent last
+	group     *group           // The group being recorded, nil if none
+	limit     int              // Maximum number of undo steps, 0 for no limit
+	replaying bool             // Whether a step is being undone or redone
+	mutex     sync.Mutex       // Mutex for the stacks, group and replaying
+	view      interfaces.IView // Local reference to View
+}
+
+/*
+group A group of commands recorded as a single step.
+*/
+type group struct {
+	entries   []entry // The commands recorded in the group
+	marks     []int   // Number of entries when each open BeginGroup was called, outermost first
+	pending   int     // Tracked executions of the group that are not over yet
+	discarded bool    // Whether the group was aborted or cleared, so it records nothing
+}
+
+var instanceMap = map[string]interfaces.IHistory{} // The Multiton History instanceMap.
+var instanceMapMutex sync.RWMutex                  // instanceMap Mutex
+
+/*
+GetInstance History Multiton Factory method.
+
+- parameter key: multitonKey
+
+- parameter factory: reference that returns IHistory
+
+- returns: the Multiton instance
+*/
+func GetInstance(key string, factory func() interfaces.IHistory) interfaces.IHistory {
+	instanceMapMutex.Lock()
+	defer instanceMapMutex.Unlock()
+
+	if instanceMap[key] == nil {
+		instanceMap[key] = factory()
+		instanceMap[key].InitializeHistory()
+	}
+	return instanceMap[key]
+}
+
+/*
+InitializeHistory Initialize the Multiton History instance.
+
+Called automatically by the GetInstance, registers
+the History as an IObserver of UNDO and REDO notifications.
+*/
+func (self *History) InitializeHistory() {
+	self.view = view.GetInstance(self.Key, func() interfaces.IView { return &view.View{Key: self.Key} })
+	self.view.RegisterObserver(UNDO, &observer.Observer{Notify: self.handleNotification, Context: self})
+	self.view.RegisterObserver(REDO, &observer.Observer{Notify: self.handleNotification, Context: self})
+}
+
+/*
+handleNotification Handle the UNDO and REDO notifications.
+*/
+func (self *History) handleNotification(notification interfaces.INotification) {
+	switch notification.Name() {
+	case UNDO:
+		self.Undo()
+	case REDO:
+		self.Redo()
+	}
+}
+
+/*
+Record Record an executed IUndoableCommand.
+
+The IUndoableCommand is recorded in the group open, if any,
+and is not recorded while a step is undone or redone.
+Recording a new step discards every step that could be redone.
+
+- parameter command: the IUndoableCommand that was executed
+
+- parameter notification: the INotification it was executed with
+*/
+func (self *History) Record(command interfaces.IUndoableCommand, notification interfaces.INotification) {
+	self.Track()(command, notification)
+}
+
+/*
+Track Capture the scope of an execution about to start.
+
+Called by the Controller when an INotification is sent, so
+an execution that runs later is recorded in the group that
+was open then, or not at all if a step was being undone or
+redone then. A group is only recorded once the executions
+tracked in it are over.
+
+- returns: the func to call exactly once when the execution is over, with the IUndoableCommand to record, or nil.
+*/
+func (self *History) Track() func(command interfaces.IUndoableCommand, notification interfaces.INotification) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.replaying {
+		return func(command interfaces.IUndoableCommand, notification interfaces.INotification) {}
+	}
+
+	var g = self.group
+	if g == nil {
+		g = &group{}
+	}
+	g.pending++
+
+	return func(command interfaces.IUndoableCommand, notification interfaces.INotification) {
+		self.mutex.Lock()
+		before := self.state()
+
+		if command != nil && g.discarded == false {
+			g.entries = append(g.entries, entry{command: command, notification: notification})
+		}
+		g.pending--
+		self.close(g)
+
+		after := self.state()
+		self.mutex.Unlock()
+
+		self.notifyChanged(before, after)
+	}
+}
+
+/*
+Undo Undo the most recently recorded step.
+
+Does nothing while another step is undone or redone.
+
+- returns: whether a step was undone.
+*/
+func (self *History) Undo() bool {
+	self.mutex.Lock()
+	if self.replaying || len(self.undoStack) == 0 {
+		self.mutex.Unlock()
+		return false
+	}
+	before := self.state()
+	step := self.undoStack[len(self.undoStack)-1]
+	self.undoStack = self.undoStack[:len(self.undoStack)-1]
+	self.replaying = true
+	self.mutex.Unlock()
+
+	defer func() {
+		self.mutex.Lock()
+		self.replaying = false
+		self.redoStack = append(self.redoStack, step)
+		after := self.state()
+		self.mutex.Unlock()
+
+		self.notifyChanged(before, after)
+	}()
+
+	for i := len(step) - 1; i >= 0; i-- {
+		step[i].command.Undo(step[i].notification)
+	}
+	return true
+}
+
+/*
+Redo Redo the most recently undone step.
+
+Does nothing while another step is undone or redone.
+
+- returns: whether a step was redone.
+*/
+func (self *History) Redo() bool {
+	self.mutex.Lock()
+	if self.replaying || len(self.redoStack) == 0 {
+		self.mutex.Unlock()
+		return false
+	}
+	before := self.state()
+	step := self.redoStack[len(self.redoStack)-1]
+	self.redoStack = self.redoStack[:len(self.redoStack)-1]
+	self.replaying = true
+	self.mutex.Unlock()
+
+	defer func() {
+		self.mutex.Lock()
+		self.replaying = false
+		self.undoStack = append(self.undoStack, step)
+		after := self.state()
+		self.mutex.Unlock()
+
+		self.notifyChanged(before, after)
+	}()
+
+	for _, e := range step {
+		e.command.Execute(e.notification)
+	}
+	return true
+}
+
+/*
+CanUndo Check if there is a step that can be undone.
+*/
+func (self *History) CanUndo() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return len(self.undoStack) > 0
+}
+
+/*
+CanRedo Check if there is a step that can be redone.
+*/
+func (self *History) CanRedo() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return len(self.redoStack) > 0
+}
+
+/*
+BeginGroup Begin a group.
+
+Every command recorded until the matching EndGroup is
+undone and redone as a single step. Groups may be nested,
+in which case the outermost group forms the step.
+*/
+func (self *History) BeginGroup() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.group == nil {
+		self.group = &group{}
+	}
+	self.group.marks = append(self.group.marks, len(self.group.entries))
+}
+
+/*
+EndGroup End the group opened by the matching BeginGroup.
+
+A group in which no commands were recorded does not form a step.
+*/
+func (self *History) EndGroup() {
+	self.endGroup(false)
+}
+
+/*
+AbortGroup End the group opened by the matching BeginGroup,
+discarding the commands recorded since.
+
+An aborted outermost group records nothing, not even the
+executions tracked in it that are not over yet.
+*/
+func (self *History) AbortGroup() {
+	self.endGroup(true)
+}
+
+/*
+endGroup End the innermost open group, keeping or discarding its commands.
+*/
+func (self *History) endGroup(abort bool) {
+	self.mutex.Lock()
+	var g = self.group
+	if g == nil {
+		self.mutex.Unlock()
+		return
+	}
+	before := self.state()
+
+	var mark = g.marks[len(g.marks)-1]
+	g.marks = g.marks[:len(g.marks)-1]
+	if abort {
+		g.entries = g.entries[:mark]
+	}
+	if len(g.marks) == 0 {
+		self.group = nil
+		g.discarded = abort
+		self.close(g)
+	}
+
+	after := self.state()
+	self.mutex.Unlock()
+
+	self.notifyChanged(before, after)
+}
+
+/*
+SetLimit Set the maximum number of undo steps kept.
+
+The oldest steps are discarded once the limit is exceeded.
+
+- parameter limit: the maximum number of undo steps, 0 for no limit
+*/
+func (self *History) SetLimit(limit int) {
+	self.mutex.Lock()
+	before := self.state()
+
+	self.limit = limit
+	self.trim()
+
+	after := self.state()
+	self.mutex.Unlock()
+
+	self.notifyChanged(before, after)
+}
+
+/*
+Clear Discard all undo and redo steps, and the group being recorded.
+*/
+func (self *History) Clear() {
+	self.mutex.Lock()
+	before := self.state()
+
+	self.undoStack = nil
+	self.redoStack = nil
+	if self.group != nil {
+		self.group.discarded = true
+		self.group = nil
+	}
+
+	after := self.state()
+	self.mutex.Unlock()
+
+	self.notifyChanged(before, after)
+}
+
+/*
+close Record a group as a step once it is ended and its
+tracked executions are over, unless it was discarded.
+
+Must be called with the mutex held.
+*/
+func (self *History) close(g *group) {
+	if len(g.marks) > 0 || g.pending > 0 || g.discarded || len(g.entries) == 0 {
+		return
+	}
+	g.discarded = true
+	self.push(g.entries)
+}
+
+/*
+push Push a step on the undo stack and discard the redo stack.
+
+Must be called with the mutex held.
+*/
+func (self *History) push(step []entry) {
+	self.undoStack = append(self.undoStack, step)
+	self.redoStack = nil
+	self.trim()
+}
+
+/*
+trim Discard the oldest undo steps beyond the limit.
+
+Must be called with the mutex held.
+*/
+func (self *History) trim() {
+	if self.limit > 0 && len(self.undoStack) > self.limit {
+		self.undoStack = append([][]entry{}, self.undoStack[len(self.undoStack)-self.limit:]...)
+	}
+}
+
+/*
+state Get the current undo/redo availability.
+
+Must be called with the mutex held.
+*/
+func (self *History) state() State {
+	return State{CanUndo: len(self.undoStack) > 0, CanRedo: len(self.redoStack) > 0}
+}
+
+/*
+notifyChanged Send HISTORY_CHANGED if the availability changed.
+*/
+func (self *History) notifyChanged(before State, after State) {
+	if before != after {
+		self.view.NotifyObservers(observer.NewNotification(HISTORY_CHANGED, &after, ""))
+	}
+}
+
+/*
+RemoveHistory Remove an IHistory instance
+
+- parameter multitonKey: of IHistory instance to remove
+*/
+func RemoveHistory(key string) {
+	instanceMapMutex.Lock()
+	defer instanceMapMutex.Unlock()
+
+	delete(instanceMap, key)
+}
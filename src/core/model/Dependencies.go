@@ -0,0 +1,196 @@
+//
+//  Dependencies.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"sort"
+	"strings"
+)
+
+var ErrMissingDependency = errors.New("missing proxy dependency") // a dependency is neither registered nor being registered
+var ErrDependencyCycle = errors.New("proxy dependency cycle")     // proxies depend on each other
+
+/*
+RegisterProxies Register IProxy instances in dependency order.
+
+Each IDependentProxy is registered after the Proxies it
+depends on, which must either be registered already or be
+among the proxies. Proxies without dependencies between them
+are registered in the given order.
+
+Nothing is registered if a dependency is missing, if the
+proxies depend on each other in a cycle, or if one of them is
+already registered under DUPLICATE_ERROR. If registering one
+fails anyway, because its name was registered meanwhile, the
+proxies registered by this call are removed again, in reverse order.
+
+- parameter proxies: the IProxy instances to register
+
+- returns: an error wrapping ErrMissingDependency, ErrDependencyCycle or ErrDuplicateProxy, naming the proxies involved.
+*/
+func (self *Model) RegisterProxies(proxies ...interfaces.IProxy) error {
+	var batch = map[string]interfaces.IProxy{}
+	var names []string
+	for _, proxy := range proxies {
+		if batch[proxy.GetProxyName()] == nil {
+			names = append(names, proxy.GetProxyName())
+		}
+		batch[proxy.GetProxyName()] = proxy
+	}
+
+	self.proxyMapMutex.RLock()
+	var policy = self.policy
+	self.proxyMapMutex.RUnlock()
+
+	for _, name := range names {
+		if policy == interfaces.DUPLICATE_ERROR && self.HasProxy(name) {
+			return fmt.Errorf("%w: %q", ErrDuplicateProxy, name)
+		}
+		for _, dependency := range dependencies(batch[name]) {
+			if batch[dependency] == nil && self.HasProxy(dependency) == false {
+				return fmt.Errorf("%w: %q depends on %q", ErrMissingDependency, name, dependency)
+			}
+		}
+	}
+
+	order, cycle := sortByDependencies(names, func(name string) []string {
+		var inBatch []string
+		for _, dependency := range dependencies(batch[name]) {
+			if batch[dependency] != nil {
+				inBatch = append(inBatch, dependency)
+			}
+		}
+		return inBatch
+	})
+	if cycle != nil {
+		return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+	}
+
+	for i, name := range order {
+		if err := self.TryRegisterProxy(batch[name]); err != nil {
+			self.unregister(order[:i], batch)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+unregister Remove the proxies of a batch still registered under their names,
+in reverse order, leaving alone the ones replaced meanwhile or ignored as duplicates.
+*/
+func (self *Model) unregister(names []string, batch map[string]interfaces.IProxy) {
+	for i := len(names) - 1; i >= 0; i-- {
+		self.proxyMapMutex.RLock()
+		var entry = self.proxyMap[names[i]]
+		var registered = entry != nil && entry.proxy == batch[names[i]]
+		self.proxyMapMutex.RUnlock()
+
+		if registered {
+			self.RemoveProxy(names[i])
+		}
+	}
+}
+
+/*
+RemoveAllProxies Remove every IProxy from the Model.
+
+Each IDependentProxy is removed before the Proxies it depends
+on. Proxies without dependencies between them are removed in
+the reverse order of their registration. Lazy Proxies that
+were not constructed are removed without being constructed.
+*/
+func (self *Model) RemoveAllProxies() {
+	self.proxyMapMutex.RLock()
+	var names = make([]string, 0, len(self.proxyMap))
+	var proxies = map[string]interfaces.IProxy{}
+	for name, entry := range self.proxyMap {
+		names = append(names, name)
+		proxies[name] = entry.proxy
+	}
+	sort.Slice(names, func(i, j int) bool { return self.proxyMap[names[i]].order < self.proxyMap[names[j]].order })
+	self.proxyMapMutex.RUnlock()
+
+	order, _ := sortByDependencies(names, func(name string) []string { return dependencies(proxies[name]) })
+	for i := len(order) - 1; i >= 0; i-- {
+		self.RemoveProxy(order[i])
+	}
+}
+
+/*
+dependencies List the dependencies of an IProxy, nil if it is not an IDependentProxy.
+*/
+func dependencies(proxy interfaces.IProxy) []string {
+	if dependent, ok := proxy.(interfaces.IDependentProxy); ok {
+		return dependent.ListProxyDependencies()
+	}
+	return nil
+}
+
+/*
+sortByDependencies Sort names so that each comes after its dependencies,
+keeping the given order where the dependencies allow.
+
+Dependencies that are not among the names are ignored.
+
+- returns: the sorted names, and the first cycle found, if any, in which case the order breaks it arbitrarily.
+*/
+func sortByDependencies(names []string, dependenciesOf func(name string) []string) ([]string, []string) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var known = map[string]bool{}
+	for _, name := range names {
+		known[name] = true
+	}
+
+	var state = map[string]int{}
+	var order []string
+	var path []string
+	var cycle []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		switch state[name] {
+		case visited:
+			return
+		case visiting:
+			if cycle == nil {
+				for i, n := range path {
+					if n == name {
+						cycle = append(append([]string{}, path[i:]...), name)
+						break
+					}
+				}
+			}
+			return
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dependency := range dependenciesOf(name) {
+			if known[dependency] {
+				visit(dependency)
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		order = append(order, name)
+	}
+
+	for _, name := range names {
+		visit(name)
+	}
+	return order, cycle
+}
@@ -9,7 +9,11 @@
 package model
 
 import (
+	"errors"
+	"fmt"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"reflect"
+	"sort"
 	"sync"
 )
 
@@ -30,13 +34,40 @@ with the Model. Typically, you use an
 ICommand to create and register IProxy
 instances once the Facade has initialized the Core
 actors.
+
+OnRegister and OnRemove are called without holding any
+lock, so they may freely register, retrieve and remove
+IProxy instances. An IProxy is retrievable while its
+OnRegister runs, and its OnRemove is never called before
+its OnRegister has returned: if it is removed meanwhile,
+OnRemove is called as soon as OnRegister returns.
+
+An IProxy may also be registered lazily, with a factory:
+it is only constructed, initialized and registered the
+first time it is retrieved.
 */
 type Model struct {
-	Key           string                       // The Multiton Key for this Core
-	proxyMap      map[string]interfaces.IProxy // Mapping of proxyNames to IProxy instances
-	proxyMapMutex sync.RWMutex                 // Mutex for proxyMap
+	Key           string                     // The Multiton Key for this Core
+	proxyMap      map[string]*proxyEntry     // Mapping of proxyNames to IProxy entries
+	proxyMapMutex sync.RWMutex               // Mutex for proxyMap
+	sequence      int                        // Registration counter, for ordering the entries
+	policy        interfaces.DuplicatePolicy // What happens when a name is registered twice
 }
 
+/*
+proxyEntry A registered IProxy and the state of its lifecycle.
+*/
+type proxyEntry struct {
+	proxy       interfaces.IProxy
+	order       int                      // when the entry was registered
+	factory     func() interfaces.IProxy // constructs a lazy IProxy, nil once it is constructed or if it is not lazy
+	construct   sync.Mutex               // Mutex ensuring a lazy IProxy is constructed once
+	registering bool                     // whether OnRegister is running
+	removed     bool                     // whether the IProxy was removed while OnRegister was running
+}
+
+var ErrDuplicateProxy = errors.New("proxy already registered") // an IProxy is registered under the name, under DUPLICATE_ERROR
+
 var instanceMap = map[string]interfaces.IModel{} // The Multiton Model instanceMap.
 var instanceMapMutex sync.RWMutex                // instanceMapMutex for thread safety
 
@@ -69,21 +100,173 @@ instance in your subclass without overriding the
 constructor.
 */
 func (self *Model) InitializeModel() {
-	self.proxyMap = map[string]interfaces.IProxy{}
+	self.proxyMap = map[string]*proxyEntry{}
 }
 
 /*
 RegisterProxy Register an IProxy with the Model.
 
+If an IProxy is already registered with the same name,
+the DuplicatePolicy applies, and RegisterProxy panics
+under DUPLICATE_ERROR.
+
 - parameter proxy: an IProxy to be held by the Model.
 */
 func (self *Model) RegisterProxy(proxy interfaces.IProxy) {
+	if err := self.TryRegisterProxy(proxy); err != nil {
+		panic(err)
+	}
+}
+
+/*
+TryRegisterProxy Register an IProxy with the Model, applying the DuplicatePolicy.
+
+- parameter proxy: an IProxy to be held by the Model.
+
+- returns: an error wrapping ErrDuplicateProxy under DUPLICATE_ERROR if the name is already registered, nil otherwise.
+*/
+func (self *Model) TryRegisterProxy(proxy interfaces.IProxy) error {
+	if ok, err := self.lockForRegistration(proxy.GetProxyName()); ok == false {
+		return err
+	}
+	proxy.InitializeNotifier(self.Key)
+	self.sequence++
+	var entry = &proxyEntry{proxy: proxy, order: self.sequence, registering: true}
+	self.proxyMap[proxy.GetProxyName()] = entry
+	self.proxyMapMutex.Unlock()
+
+	self.onRegister(entry)
+	return nil
+}
+
+/*
+SetDuplicatePolicy Set what happens when an IProxy is registered
+under a name that is already registered.
+
+- parameter policy: the DuplicatePolicy, DUPLICATE_DEFAULT to replace
+*/
+func (self *Model) SetDuplicatePolicy(policy interfaces.DuplicatePolicy) {
 	self.proxyMapMutex.Lock()
 	defer self.proxyMapMutex.Unlock()
 
+	self.policy = policy
+}
+
+/*
+lockForRegistration Lock the proxyMap once the name is free,
+applying the DuplicatePolicy if it is registered.
+
+- returns: true with the proxyMap locked if the registration may proceed, false with the error to return otherwise.
+*/
+func (self *Model) lockForRegistration(proxyName string) (bool, error) {
+	self.proxyMapMutex.Lock()
+	for self.proxyMap[proxyName] != nil {
+		switch self.policy {
+		case interfaces.DUPLICATE_ERROR:
+			self.proxyMapMutex.Unlock()
+			return false, fmt.Errorf("%w: %q", ErrDuplicateProxy, proxyName)
+		case interfaces.DUPLICATE_IGNORE:
+			self.proxyMapMutex.Unlock()
+			return false, nil
+		default:
+			self.proxyMapMutex.Unlock()
+			self.RemoveProxy(proxyName)
+			self.proxyMapMutex.Lock()
+		}
+	}
+	return true, nil
+}
+
+/*
+RegisterLazyProxy Register a factory of an IProxy with the Model.
+
+The IProxy is constructed, has its notifier initialized and
+its OnRegister called the first time it is retrieved. Until
+then HasProxy and ListProxies report it, FindProxies and
+SaveSnapshot skip it, RestoreSnapshot constructs it if the
+snapshot has a state for it, and RemoveProxy removes the
+factory without constructing it.
+
+The factory must not retrieve the IProxy it constructs.
+
+- parameter proxyName: the name to register the IProxy under
+
+- parameter factory: reference that returns the IProxy
+*/
+func (self *Model) RegisterLazyProxy(proxyName string, factory func() interfaces.IProxy) {
+	if ok, err := self.lockForRegistration(proxyName); ok == false {
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	defer self.proxyMapMutex.Unlock()
+
+	self.sequence++
+	self.proxyMap[proxyName] = &proxyEntry{order: self.sequence, factory: factory}
+}
+
+/*
+onRegister Call OnRegister on the IProxy of an entry,
+then OnRemove if it was removed meanwhile.
+*/
+func (self *Model) onRegister(entry *proxyEntry) {
+	entry.proxy.OnRegister()
+
+	self.proxyMapMutex.Lock()
+	entry.registering = false
+	var removed = entry.removed
+	self.proxyMapMutex.Unlock()
+
+	// removed while OnRegister was running
+	if removed {
+		entry.proxy.OnRemove()
+	}
+}
+
+/*
+construct Construct the IProxy of a lazy entry, once.
+
+Other goroutines retrieving it wait until it is constructed,
+and retrieve it while its OnRegister runs. If the factory
+panics, the entry stays lazy and the panic goes on.
+
+- returns: the IProxy, nil if the entry was removed before it was constructed.
+*/
+func (self *Model) construct(proxyName string, entry *proxyEntry) interfaces.IProxy {
+	proxy, constructed := self.constructOnce(proxyName, entry)
+	if constructed {
+		self.onRegister(entry)
+	}
+	return proxy
+}
+
+/*
+constructOnce Construct the IProxy of a lazy entry unless another goroutine did.
+
+- returns: the IProxy, and whether this call constructed it.
+*/
+func (self *Model) constructOnce(proxyName string, entry *proxyEntry) (interfaces.IProxy, bool) {
+	entry.construct.Lock()
+	defer entry.construct.Unlock()
+
+	self.proxyMapMutex.RLock()
+	var proxy, factory = entry.proxy, entry.factory
+	self.proxyMapMutex.RUnlock()
+	if proxy != nil || factory == nil {
+		return proxy, false
+	}
+
+	proxy = factory()
 	proxy.InitializeNotifier(self.Key)
-	self.proxyMap[proxy.GetProxyName()] = proxy
-	proxy.OnRegister()
+
+	self.proxyMapMutex.Lock()
+	defer self.proxyMapMutex.Unlock()
+	if self.proxyMap[proxyName] != entry {
+		return nil, false
+	}
+	entry.proxy, entry.factory, entry.registering = proxy, nil, true
+	return proxy, true
 }
 
 /*
@@ -95,9 +278,18 @@ RetrieveProxy Retrieve an IProxy from the Model.
 */
 func (self *Model) RetrieveProxy(proxyName string) interfaces.IProxy {
 	self.proxyMapMutex.RLock()
-	defer self.proxyMapMutex.RUnlock()
+	var entry = self.proxyMap[proxyName]
+	if entry == nil {
+		self.proxyMapMutex.RUnlock()
+		return nil
+	}
+	var proxy = entry.proxy
+	self.proxyMapMutex.RUnlock()
 
-	return self.proxyMap[proxyName]
+	if proxy == nil {
+		return self.construct(proxyName, entry)
+	}
+	return proxy
 }
 
 /*
@@ -105,18 +297,25 @@ RemoveProxy Remove an IProxy from the Model.
 
 - parameter proxyName: name of the IProxy instance to be removed.
 
-- returns: the IProxy that was removed from the Model
+- returns: the IProxy that was removed from the Model, nil for a lazy IProxy that was never constructed
 */
 func (self *Model) RemoveProxy(proxyName string) interfaces.IProxy {
 	self.proxyMapMutex.Lock()
-	defer self.proxyMapMutex.Unlock()
-
-	var proxy = self.proxyMap[proxyName]
-	if proxy != nil {
-		delete(self.proxyMap, proxyName)
-		proxy.OnRemove()
+	var entry = self.proxyMap[proxyName]
+	if entry == nil {
+		self.proxyMapMutex.Unlock()
+		return nil
 	}
-	return proxy
+	delete(self.proxyMap, proxyName)
+	var deferred = entry.registering
+	entry.removed = deferred
+	self.proxyMapMutex.Unlock()
+
+	// OnRemove is deferred to RegisterProxy while OnRegister is running
+	if deferred == false && entry.proxy != nil {
+		entry.proxy.OnRemove()
+	}
+	return entry.proxy
 }
 
 /*
@@ -133,14 +332,123 @@ func (self *Model) HasProxy(proxyName string) bool {
 	return self.proxyMap[proxyName] != nil
 }
 
+/*
+ListProxies List the names of the registered Proxies.
+
+- returns: the Proxy names, sorted.
+*/
+func (self *Model) ListProxies() []string {
+	self.proxyMapMutex.RLock()
+	var names = make([]string, 0, len(self.proxyMap))
+	for name := range self.proxyMap {
+		names = append(names, name)
+	}
+	self.proxyMapMutex.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+/*
+ProxyCount Count the registered Proxies.
+
+- returns: the number of registered Proxies.
+*/
+func (self *Model) ProxyCount() int {
+	self.proxyMapMutex.RLock()
+	defer self.proxyMapMutex.RUnlock()
+
+	return len(self.proxyMap)
+}
+
+/*
+FindProxies Find the Proxies matching a predicate.
+
+The predicate is called outside any lock, on a snapshot
+of the registered Proxies, so it may use the Model.
+Lazy Proxies that were not constructed yet are skipped.
+
+- parameter predicate: called with each IProxy
+
+- returns: the matching IProxy instances, sorted by name.
+*/
+func (self *Model) FindProxies(predicate func(proxy interfaces.IProxy) bool) []interfaces.IProxy {
+	self.proxyMapMutex.RLock()
+	var proxies = make([]interfaces.IProxy, 0, len(self.proxyMap))
+	for _, entry := range self.proxyMap {
+		if entry.proxy != nil {
+			proxies = append(proxies, entry.proxy)
+		}
+	}
+	self.proxyMapMutex.RUnlock()
+
+	sort.Slice(proxies, func(i, j int) bool { return proxies[i].GetProxyName() < proxies[j].GetProxyName() })
+
+	var matches = []interfaces.IProxy{}
+	for _, proxy := range proxies {
+		if predicate(proxy) {
+			matches = append(matches, proxy)
+		}
+	}
+	return matches
+}
+
+/*
+FindProxiesOfType Find the Proxies of a Go type, or implementing an interface type.
+
+For example, every IProxy implementing io.Closer:
+
+	model.FindProxiesOfType(reflect.TypeOf((*io.Closer)(nil)).Elem())
+
+- parameter _type: the concrete type of the IProxy, or an interface type it implements
+
+- returns: the matching IProxy instances, sorted by name.
+*/
+func (self *Model) FindProxiesOfType(_type reflect.Type) []interfaces.IProxy {
+	return self.FindProxies(func(proxy interfaces.IProxy) bool {
+		if _type.Kind() == reflect.Interface {
+			return reflect.TypeOf(proxy).Implements(_type)
+		}
+		return reflect.TypeOf(proxy) == _type
+	})
+}
+
+/*
+ProxiesOf Find the Proxies of a Go type, or implementing an interface type, as that type.
+
+	for _, closer := range model.ProxiesOf[io.Closer](facade) {
+	  closer.Close()
+	}
+
+- parameter model: the IModel, or IFacade, to search
+
+- returns: the matching Proxies, sorted by name.
+*/
+func ProxiesOf[T any](model interface {
+	FindProxies(predicate func(proxy interfaces.IProxy) bool) []interfaces.IProxy
+}) []T {
+	var matches = []T{}
+	for _, proxy := range model.FindProxies(func(proxy interfaces.IProxy) bool { _, ok := proxy.(T); return ok }) {
+		matches = append(matches, proxy.(T))
+	}
+	return matches
+}
+
 /*
 RemoveModel Remove an IModel instance
 
+Its Proxies are then removed with RemoveAllProxies, so each
+has OnRemove called, dependents before their dependencies.
+
 - parameter multitonKey: of IModel instance to remove
 */
 func RemoveModel(key string) {
 	instanceMapMutex.Lock()
-	defer instanceMapMutex.Unlock()
-
+	var model = instanceMap[key]
 	delete(instanceMap, key)
+	instanceMapMutex.Unlock()
+
+	if model != nil {
+		model.RemoveAllProxies()
+	}
 }
@@ -0,0 +1,125 @@
+//
+//  Snapshot.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package model
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"io"
+	"sort"
+)
+
+/*
+SaveSnapshot Save the state of every registered ISnapshotProxy.
+
+The snapshot maps the name of each ISnapshotProxy to the
+state returned by its Snapshot method. Proxies that are not
+ISnapshotProxy instances are left out, and so are lazy Proxies
+that were not constructed yet, their state being the initial one.
+
+- parameter writer: where to write the snapshot
+
+- parameter format: SNAPSHOT_JSON or SNAPSHOT_GOB
+
+- returns: an error naming the IProxy whose state could not be saved.
+*/
+func (self *Model) SaveSnapshot(writer io.Writer, format interfaces.SnapshotFormat) error {
+	var states = map[string]interface{}{}
+	for _, proxy := range self.FindProxies(isSnapshotProxy) {
+		state, err := proxy.(interfaces.ISnapshotProxy).Snapshot()
+		if err != nil {
+			return fmt.Errorf("snapshot of proxy %q: %w", proxy.GetProxyName(), err)
+		}
+		states[proxy.GetProxyName()] = state
+	}
+
+	switch format {
+	case interfaces.SNAPSHOT_JSON:
+		return json.NewEncoder(writer).Encode(states)
+	case interfaces.SNAPSHOT_GOB:
+		var encoded = map[string][]byte{}
+		for name, state := range states {
+			var buffer bytes.Buffer
+			if err := gob.NewEncoder(&buffer).Encode(state); err != nil {
+				return fmt.Errorf("snapshot of proxy %q: %w", name, err)
+			}
+			encoded[name] = buffer.Bytes()
+		}
+		return gob.NewEncoder(writer).Encode(encoded)
+	default:
+		return fmt.Errorf("unknown snapshot format %d", format)
+	}
+}
+
+/*
+RestoreSnapshot Restore the state of the registered ISnapshotProxy instances from a snapshot.
+
+Each registered ISnapshotProxy with a state in the snapshot
+has its Restore method called, in the order of their names.
+A lazy IProxy with a state is constructed first. Proxies without
+a state, and states without a registered ISnapshotProxy, are left alone.
+
+- parameter reader: where to read the snapshot from
+
+- parameter format: SNAPSHOT_JSON or SNAPSHOT_GOB
+
+- returns: an error naming the IProxy whose state could not be restored.
+*/
+func (self *Model) RestoreSnapshot(reader io.Reader, format interfaces.SnapshotFormat) error {
+	var decoders = map[string]func(target interface{}) error{}
+
+	switch format {
+	case interfaces.SNAPSHOT_JSON:
+		var states map[string]json.RawMessage
+		if err := json.NewDecoder(reader).Decode(&states); err != nil {
+			return fmt.Errorf("snapshot: %w", err)
+		}
+		for name, state := range states {
+			state := state
+			decoders[name] = func(target interface{}) error { return json.Unmarshal(state, target) }
+		}
+	case interfaces.SNAPSHOT_GOB:
+		var states map[string][]byte
+		if err := gob.NewDecoder(reader).Decode(&states); err != nil {
+			return fmt.Errorf("snapshot: %w", err)
+		}
+		for name, state := range states {
+			state := state
+			decoders[name] = func(target interface{}) error { return gob.NewDecoder(bytes.NewReader(state)).Decode(target) }
+		}
+	default:
+		return fmt.Errorf("unknown snapshot format %d", format)
+	}
+
+	var names = make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if proxy, ok := self.RetrieveProxy(name).(interfaces.ISnapshotProxy); ok {
+			if err := proxy.Restore(decoders[name]); err != nil {
+				return fmt.Errorf("restore of proxy %q: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+isSnapshotProxy Check if an IProxy is an ISnapshotProxy.
+*/
+func isSnapshotProxy(proxy interfaces.IProxy) bool {
+	_, ok := proxy.(interfaces.ISnapshotProxy)
+	return ok
+}
@@ -9,11 +9,16 @@
 package view
 
 import (
+	"errors"
+	"fmt"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"sort"
 	"sync"
 )
 
+var ErrDuplicateMediator = errors.New("mediator already registered") // an IMediator is registered under the name, under DUPLICATE_ERROR
+
 /*
 View A Multiton IView implementation.
 In PureMVC, the View class assumes these responsibilities:
@@ -31,13 +36,31 @@ In PureMVC, the View class assumes these responsibilities:
 * Providing a method for broadcasting an INotification.
 
 * Notifying the IObservers of a given INotification when it broadcast.
+
+OnRegister and OnRemove are called without holding any
+lock, so they may freely register, retrieve and remove
+IMediator instances. An IMediator is retrievable while its
+OnRegister runs, and its OnRemove is never called before
+its OnRegister has returned: if it is removed meanwhile,
+its observers are removed and OnRemove is called as soon
+as OnRegister returns.
 */
 type View struct {
 	Key              string
-	mediatorMap      map[string]interfaces.IMediator   // Mapping of Mediator names to Mediator instances
+	mediatorMap      map[string]*mediatorEntry         // Mapping of Mediator names to Mediator entries
 	observerMap      map[string][]interfaces.IObserver // Mapping of Notification names to Observer lists
 	mediatorMapMutex sync.RWMutex                      // Mutex for mediatorMap
 	observerMapMutex sync.RWMutex                      // Mutex for observerMap
+	policy           interfaces.DuplicatePolicy        // What happens when a name is registered twice
+}
+
+/*
+mediatorEntry A registered IMediator and the state of its lifecycle.
+*/
+type mediatorEntry struct {
+	mediator    interfaces.IMediator
+	registering bool // whether the observers are being registered or OnRegister is running
+	removed     bool // whether the IMediator was removed while registering
 }
 
 var instanceMap = map[string]interfaces.IView{} // The Multiton View instanceMap.
@@ -72,7 +95,7 @@ instance in your subclass without overriding the
 constructor.
 */
 func (self *View) InitializeView() {
-	self.mediatorMap = map[string]interfaces.IMediator{}
+	self.mediatorMap = map[string]*mediatorEntry{}
 	self.observerMap = map[string][]interfaces.IObserver{}
 }
 
@@ -145,7 +168,8 @@ func (self *View) RemoveObserver(notificationName string, notifyContext interfac
 		if observer.CompareNotifyContext(notifyContext) == true {
 			// there can only be one Observer for a given notifyContext
 			// in any given Observer list, so remove it and break
-			observers = append(observers[:index], observers[index+1:]...)
+			observers = append(observers[:index:index], observers[index+1:]...)
+			self.observerMap[notificationName] = observers
 			break
 		}
 	}
@@ -170,21 +194,51 @@ the IMediator instance's handleNotification method
 and registering it as an Observer for all INotifications the
 IMediator is interested in.
 
+If an IMediator is already registered with the same name,
+the DuplicatePolicy applies, and RegisterMediator panics
+under DUPLICATE_ERROR.
+
 - parameter mediator: a reference to the IMediator instance
 */
 func (self *View) RegisterMediator(mediator interfaces.IMediator) {
+	if err := self.TryRegisterMediator(mediator); err != nil {
+		panic(err)
+	}
+}
+
+/*
+TryRegisterMediator Register an IMediator instance with the View, applying the DuplicatePolicy.
+
+- parameter mediator: a reference to the IMediator instance
+
+- returns: an error wrapping ErrDuplicateMediator under DUPLICATE_ERROR if the name is already registered, nil otherwise.
+*/
+func (self *View) TryRegisterMediator(mediator interfaces.IMediator) error {
+	var mediatorName = mediator.GetMediatorName()
 	self.mediatorMapMutex.Lock()
-	defer self.mediatorMapMutex.Unlock()
 
-	// do not allow re-registration (you must removeMediator fist)
-	if self.mediatorMap[mediator.GetMediatorName()] != nil {
-		return
+	for self.mediatorMap[mediatorName] != nil {
+		switch self.policy {
+		case interfaces.DUPLICATE_ERROR:
+			self.mediatorMapMutex.Unlock()
+			return fmt.Errorf("%w: %q", ErrDuplicateMediator, mediatorName)
+		case interfaces.DUPLICATE_REPLACE:
+			self.mediatorMapMutex.Unlock()
+			self.RemoveMediator(mediatorName)
+			self.mediatorMapMutex.Lock()
+		default:
+			// do not allow re-registration (you must removeMediator fist)
+			self.mediatorMapMutex.Unlock()
+			return nil
+		}
 	}
 
 	mediator.InitializeNotifier(self.Key)
 
 	// Register the Mediator for retrieval by name
-	self.mediatorMap[mediator.GetMediatorName()] = mediator
+	var entry = &mediatorEntry{mediator: mediator, registering: true}
+	self.mediatorMap[mediator.GetMediatorName()] = entry
+	self.mediatorMapMutex.Unlock()
 
 	// Get Notification interests, if any.
 	interests := mediator.ListNotificationInterests()
@@ -202,6 +256,31 @@ func (self *View) RegisterMediator(mediator interfaces.IMediator) {
 	}
 	// alert the mediator that it has been registered
 	mediator.OnRegister()
+
+	self.mediatorMapMutex.Lock()
+	entry.registering = false
+	var removed = entry.removed
+	self.mediatorMapMutex.Unlock()
+
+	// removed while registering
+	if removed {
+		self.removeObservers(mediator)
+		mediator.OnRemove()
+	}
+	return nil
+}
+
+/*
+SetDuplicatePolicy Set what happens when an IMediator is registered
+under a name that is already registered.
+
+- parameter policy: the DuplicatePolicy, DUPLICATE_DEFAULT to ignore
+*/
+func (self *View) SetDuplicatePolicy(policy interfaces.DuplicatePolicy) {
+	self.mediatorMapMutex.Lock()
+	defer self.mediatorMapMutex.Unlock()
+
+	self.policy = policy
 }
 
 /*
@@ -215,7 +294,10 @@ func (self *View) RetrieveMediator(mediatorName string) interfaces.IMediator {
 	self.mediatorMapMutex.RLock()
 	defer self.mediatorMapMutex.RUnlock()
 
-	return self.mediatorMap[mediatorName]
+	if entry := self.mediatorMap[mediatorName]; entry != nil {
+		return entry.mediator
+	}
+	return nil
 }
 
 /*
@@ -227,28 +309,42 @@ RemoveMediator Remove an IMediator from the View.
 */
 func (self *View) RemoveMediator(mediatorName string) interfaces.IMediator {
 	self.mediatorMapMutex.Lock()
-	defer self.mediatorMapMutex.Unlock()
 
 	// Retrieve the named mediator
-	var mediator = self.mediatorMap[mediatorName]
-
-	if mediator != nil {
-		// for every notification this mediator is interested in...
-		interests := mediator.ListNotificationInterests()
+	var entry = self.mediatorMap[mediatorName]
+	if entry == nil {
+		self.mediatorMapMutex.Unlock()
+		return nil
+	}
 
-		for _, interest := range interests {
-			// remove the observer linking the mediator
-			// to the notification interest
-			self.RemoveObserver(interest, mediator)
-		}
+	// remove the mediator from the map
+	delete(self.mediatorMap, mediatorName)
+	var deferred = entry.registering
+	entry.removed = deferred
+	self.mediatorMapMutex.Unlock()
 
-		// remove the mediator from the map
-		delete(self.mediatorMap, mediatorName)
+	// removing the observers and OnRemove are deferred to RegisterMediator while it is registering
+	if deferred == false {
+		self.removeObservers(entry.mediator)
 
 		// alert the mediator that it has been removed
-		mediator.OnRemove()
+		entry.mediator.OnRemove()
+	}
+	return entry.mediator
+}
+
+/*
+removeObservers Remove the observers linking a mediator to its notification interests.
+*/
+func (self *View) removeObservers(mediator interfaces.IMediator) {
+	// for every notification this mediator is interested in...
+	interests := mediator.ListNotificationInterests()
+
+	for _, interest := range interests {
+		// remove the observer linking the mediator
+		// to the notification interest
+		self.RemoveObserver(interest, mediator)
 	}
-	return mediator
 }
 
 /*
@@ -260,11 +356,120 @@ HasMediator Check if a Mediator is registered or not
 */
 func (self *View) HasMediator(mediatorName string) bool {
 	self.mediatorMapMutex.RLock()
-	self.mediatorMapMutex.RUnlock()
+	defer self.mediatorMapMutex.RUnlock()
 
 	return self.mediatorMap[mediatorName] != nil
 }
 
+/*
+ListMediators List the names of the registered Mediators.
+
+- returns: the Mediator names, sorted.
+*/
+func (self *View) ListMediators() []string {
+	self.mediatorMapMutex.RLock()
+	var names = make([]string, 0, len(self.mediatorMap))
+	for name := range self.mediatorMap {
+		names = append(names, name)
+	}
+	self.mediatorMapMutex.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+/*
+MediatorCount Count the registered Mediators.
+
+- returns: the number of registered Mediators.
+*/
+func (self *View) MediatorCount() int {
+	self.mediatorMapMutex.RLock()
+	defer self.mediatorMapMutex.RUnlock()
+
+	return len(self.mediatorMap)
+}
+
+/*
+ListMediatorInterests List the Notification names a Mediator is observing.
+
+These are the interests the IMediator was registered with,
+as found in the observer map, rather than what
+ListNotificationInterests returns now.
+
+- parameter mediatorName: the name of the IMediator
+
+- returns: the Notification names, sorted, nil if no Mediator is registered with the given mediatorName.
+*/
+func (self *View) ListMediatorInterests(mediatorName string) []string {
+	var mediator = self.RetrieveMediator(mediatorName)
+	if mediator == nil {
+		return nil
+	}
+
+	self.observerMapMutex.RLock()
+	var interests = []string{}
+	for name, observers := range self.observerMap {
+		for _, observer := range observers {
+			if observer.CompareNotifyContext(mediator) {
+				interests = append(interests, name)
+				break
+			}
+		}
+	}
+	self.observerMapMutex.RUnlock()
+
+	sort.Strings(interests)
+	return interests
+}
+
+/*
+ListNotifications List the Notification names that have Observers.
+
+- returns: the Notification names, sorted.
+*/
+func (self *View) ListNotifications() []string {
+	self.observerMapMutex.RLock()
+	var names = make([]string, 0, len(self.observerMap))
+	for name, observers := range self.observerMap {
+		if len(observers) > 0 {
+			names = append(names, name)
+		}
+	}
+	self.observerMapMutex.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+/*
+ListObservers List the Observers of a Notification.
+
+- parameter notificationName: the name of the INotification
+
+- returns: a copy of the IObserver list, in the order they are notified.
+*/
+func (self *View) ListObservers(notificationName string) []interfaces.IObserver {
+	self.observerMapMutex.RLock()
+	defer self.observerMapMutex.RUnlock()
+
+	return append([]interfaces.IObserver{}, self.observerMap[notificationName]...)
+}
+
+/*
+ObserverCount Count the Observers of a Notification.
+
+- parameter notificationName: the name of the INotification
+
+- returns: the number of IObservers notified of the given notificationName.
+*/
+func (self *View) ObserverCount(notificationName string) int {
+	self.observerMapMutex.RLock()
+	defer self.observerMapMutex.RUnlock()
+
+	return len(self.observerMap[notificationName])
+}
+
 /*
 RemoveView Remove an IView instance
 
@@ -0,0 +1,20 @@
+//
+//  CommandFunc.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+import "context"
+
+/*
+CommandFunc A plain func handling an INotification like an ICommand.
+
+It is called with the context.Context of the execution,
+the INotification, and the IFacade of its Core. A returned
+error is reported like the error of an IContextCommand.
+*/
+type CommandFunc func(ctx context.Context, notification INotification, facade IFacade) error
@@ -0,0 +1,46 @@
+//
+//  CommandOptions.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+import "time"
+
+/*
+CommandOptions The options of an ICommand to INotification mapping.
+
+Set by passing CommandOption funcs when registering the
+ICommand with the IController.
+*/
+type CommandOptions struct {
+	Timeout time.Duration // deadline for each execution, 0 for none
+	Retry   IRetryPolicy  // policy for retrying failed executions, nil for none
+
+	SerialKey func(notification INotification) string // chooses the serial lane of an execution, nil to execute immediately
+
+	RateLimit   float64        // executions started per second, 0 for no limit
+	Burst       int            // executions that may start at once under the RateLimit, 1 if 0
+	MaxInFlight int            // executions running at once, 0 for no limit
+	Overflow    OverflowPolicy // what happens to executions over the RateLimit or MaxInFlight
+}
+
+/*
+OverflowPolicy What happens to an execution that would exceed
+the RateLimit or MaxInFlight of its mapping.
+*/
+type OverflowPolicy int
+
+const (
+	OVERFLOW_REJECT OverflowPolicy = iota // the execution is skipped and reported as an error
+	OVERFLOW_QUEUE                        // the execution waits on its own goroutine until it is allowed to start
+	OVERFLOW_DROP                         // the execution is silently skipped
+)
+
+/*
+CommandOption A func that sets an option of an ICommand to INotification mapping.
+*/
+type CommandOption func(options *CommandOptions)
@@ -0,0 +1,22 @@
+//
+//  DuplicatePolicy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+DuplicatePolicy What happens when an IProxy or IMediator is
+registered under a name that is already registered.
+*/
+type DuplicatePolicy int
+
+const (
+	DUPLICATE_DEFAULT DuplicatePolicy = iota // an IModel replaces, an IView ignores
+	DUPLICATE_ERROR                          // the registration fails, TryRegister returns an error and Register panics with it
+	DUPLICATE_REPLACE                        // the registered instance is removed, with OnRemove, before the new one is registered
+	DUPLICATE_IGNORE                         // the new instance is not registered
+)
@@ -0,0 +1,27 @@
+//
+//  ICompensableCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+ICompensableCommand The interface definition for a PureMVC Command
+that declares a compensating action.
+
+When a later step of a SagaCommand fails, every step that has
+already completed is compensated in reverse order.
+*/
+type ICompensableCommand interface {
+	ICommand
+
+	/*
+	  Compensate for a previous successful call to Execute.
+
+	  - parameter notification: the INotification originally passed to Execute.
+	*/
+	Compensate(notification INotification)
+}
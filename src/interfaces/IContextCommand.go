@@ -0,0 +1,40 @@
+//
+//  IContextCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+import "context"
+
+/*
+IContextCommand The interface definition for a PureMVC Command
+that honors a context.Context and can report a failure.
+
+The IController initializes the context.Context of an
+IContextCommand before calling Execute, and checks Err
+once Execute returns. The context.Context is cancelled
+when the execution exceeds the timeout of its mapping.
+*/
+type IContextCommand interface {
+	ICommand
+
+	/*
+	  Initialize the context.Context of this execution.
+
+	  Called by the IController or MacroCommand before Execute.
+
+	  - parameter ctx: the context.Context of this execution
+	*/
+	InitializeContext(ctx context.Context)
+
+	/*
+	  Get the error this ICommand failed with.
+
+	  - returns: the error, nil if Execute succeeded.
+	*/
+	Err() error
+}
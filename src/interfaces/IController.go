@@ -35,8 +35,9 @@ type IController interface {
 
 	  - parameter notificationName: the name of the INotification
 	  - parameter factory: reference that returns ICommand
+	  - parameter options: CommandOption funcs applied to the mapping (optional)
 	*/
-	RegisterCommand(notificationName string, factory func() ICommand)
+	RegisterCommand(notificationName string, factory func() ICommand, options ...CommandOption)
 
 	/*
 	  Execute the ICommand previously registered as the
@@ -60,4 +61,18 @@ type IController interface {
 	  - returns: whether a Command is currently registered for the given notificationName.
 	*/
 	HasCommand(notificationName string) bool
+
+	/*
+	  List the Notification names Commands are registered for.
+
+	  - returns: the Notification names, sorted.
+	*/
+	ListCommands() []string
+
+	/*
+	  Count the registered Commands.
+
+	  - returns: the number of Notification names Commands are registered for.
+	*/
+	CommandCount() int
 }
@@ -0,0 +1,32 @@
+//
+//  IDedupeStore.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+IDedupeStore The interface definition for a store of the
+keys of IIdempotentCommand executions.
+
+Implementations must be safe for concurrent use.
+*/
+type IDedupeStore interface {
+	/*
+	  Claim a key.
+
+	  - parameter key: the key of an execution
+	  - returns: true if the key was not claimed yet, false for a duplicate.
+	*/
+	Claim(key string) bool
+
+	/*
+	  Release a claimed key, so it may be claimed again.
+
+	  - parameter key: the key of an execution
+	*/
+	Release(key string)
+}
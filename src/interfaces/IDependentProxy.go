@@ -0,0 +1,29 @@
+//
+//  IDependentProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+IDependentProxy The interface definition for a PureMVC Proxy
+that depends on other Proxies.
+
+When registered together with IModel.RegisterProxies, an
+IDependentProxy is registered after its dependencies, so its
+OnRegister may retrieve them. When its IModel is torn down,
+it is removed before its dependencies.
+*/
+type IDependentProxy interface {
+	IProxy
+
+	/*
+	  List the names of the Proxies this IProxy depends on.
+
+	  - returns: the Proxy names.
+	*/
+	ListProxyDependencies() []string
+}
@@ -8,6 +8,11 @@
 
 package interfaces
 
+import (
+	"io"
+	"reflect"
+)
+
 /*
 IFacade The interface definition for a PureMVC Facade.
 
@@ -51,8 +56,18 @@ type IFacade interface {
 
 	  - parameter noteName: the name of the INotification to associate the ICommand with.
 	  - parameter factory: reference that returns ICommand
+	  - parameter options: CommandOption funcs applied to the mapping (optional)
+	*/
+	RegisterCommand(notificationName string, factory func() ICommand, options ...CommandOption)
+
+	/*
+	  Register a CommandFunc with the Controller.
+
+	  - parameter noteName: the name of the INotification to associate the CommandFunc with.
+	  - parameter handler: the CommandFunc
+	  - parameter options: CommandOption funcs applied to the mapping (optional)
 	*/
-	RegisterCommand(notificationName string, factory func() ICommand)
+	RegisterCommandFunc(notificationName string, handler CommandFunc, options ...CommandOption)
 
 	/*
 	  Remove a previously registered ICommand to INotification mapping from the Controller.
@@ -69,6 +84,20 @@ type IFacade interface {
 	*/
 	HasCommand(notificationName string) bool
 
+	/*
+	  List the Notification names Commands are registered for.
+
+	  - returns: the Notification names, sorted.
+	*/
+	ListCommands() []string
+
+	/*
+	  Count the registered Commands.
+
+	  - returns: the number of Notification names Commands are registered for.
+	*/
+	CommandCount() int
+
 	/*
 	  Register an IProxy with the Model by name.
 
@@ -76,6 +105,30 @@ type IFacade interface {
 	*/
 	RegisterProxy(proxy IProxy)
 
+	/*
+	  Register an IProxy with the Model by name, applying the DuplicatePolicy.
+
+	  - parameter proxy: the IProxy to be registered with the Model.
+	  - returns: an error under DUPLICATE_ERROR if an IProxy is already registered with the same name.
+	*/
+	TryRegisterProxy(proxy IProxy) error
+
+	/*
+	  Register IProxy instances, each IDependentProxy after the Proxies it depends on.
+
+	  - parameter proxies: the IProxy instances to register
+	  - returns: an error if a dependency is missing, the proxies depend on each other in a cycle, or one of them is already registered under DUPLICATE_ERROR, in which case nothing is registered.
+	*/
+	RegisterProxies(proxies ...IProxy) error
+
+	/*
+	  Register a factory of an IProxy, constructed the first time it is retrieved.
+
+	  - parameter proxyName: the name to register the IProxy under
+	  - parameter factory: reference that returns the IProxy
+	*/
+	RegisterLazyProxy(proxyName string, factory func() IProxy)
+
 	/*
 	  Retrieve a IProxy from the Model by name.
 
@@ -92,6 +145,11 @@ type IFacade interface {
 	*/
 	RemoveProxy(proxyName string) IProxy
 
+	/*
+	  Remove every IProxy, each IDependentProxy before the Proxies it depends on.
+	*/
+	RemoveAllProxies()
+
 	/*
 	  Check if a Proxy is registered
 
@@ -100,6 +158,54 @@ type IFacade interface {
 	*/
 	HasProxy(proxyName string) bool
 
+	/*
+	  List the names of the registered Proxies.
+
+	  - returns: the Proxy names, sorted.
+	*/
+	ListProxies() []string
+
+	/*
+	  Count the registered Proxies.
+
+	  - returns: the number of registered Proxies.
+	*/
+	ProxyCount() int
+
+	/*
+	  Find the Proxies matching a predicate.
+
+	  - parameter predicate: called with each IProxy, outside any lock
+	  - returns: the matching IProxy instances, sorted by name.
+	*/
+	FindProxies(predicate func(proxy IProxy) bool) []IProxy
+
+	/*
+	  Find the Proxies of a Go type, or implementing an interface type.
+
+	  - parameter _type: the concrete type, e.g. reflect.TypeOf(&UserProxy{}), or the interface type, e.g. reflect.TypeOf((*io.Closer)(nil)).Elem()
+	  - returns: the matching IProxy instances, sorted by name.
+	*/
+	FindProxiesOfType(_type reflect.Type) []IProxy
+
+	/*
+	  Save the state of every registered ISnapshotProxy.
+
+	  - parameter writer: where to write the snapshot
+	  - parameter format: the encoding of the snapshot
+	  - returns: an error naming the IProxy whose state could not be saved.
+	*/
+	SaveSnapshot(writer io.Writer, format SnapshotFormat) error
+
+	/*
+	  Restore the state of the registered ISnapshotProxy instances from a snapshot.
+
+	  - parameter reader: where to read the snapshot from
+	  - parameter format: the encoding of the snapshot
+	  - returns: an error naming the IProxy whose state could not be restored.
+	*/
+	RestoreSnapshot(reader io.Reader, format SnapshotFormat) error
+
 	/*
 	  Register an IMediator instance with the View.
 
@@ -107,6 +213,21 @@ type IFacade interface {
 	*/
 	RegisterMediator(mediator IMediator)
 
+	/*
+	  Register an IMediator instance with the View, applying the DuplicatePolicy.
+
+	  - parameter mediator: a reference to the IMediator instance
+	  - returns: an error under DUPLICATE_ERROR if an IMediator is already registered with the same name.
+	*/
+	TryRegisterMediator(mediator IMediator) error
+
+	/*
+	  Set what happens when an IProxy or IMediator is registered under a name that is already registered in this Core.
+
+	  - parameter policy: the DuplicatePolicy
+	*/
+	SetDuplicatePolicy(policy DuplicatePolicy)
+
 	/*
 	  Retrieve an IMediator instance from the View.
 
@@ -131,6 +252,51 @@ type IFacade interface {
 	*/
 	HasMediator(mediatorName string) bool
 
+	/*
+	  List the names of the registered Mediators.
+
+	  - returns: the Mediator names, sorted.
+	*/
+	ListMediators() []string
+
+	/*
+	  Count the registered Mediators.
+
+	  - returns: the number of registered Mediators.
+	*/
+	MediatorCount() int
+
+	/*
+	  List the Notification names a Mediator is observing.
+
+	  - parameter mediatorName: the name of the IMediator
+	  - returns: the Notification names, sorted, nil if no Mediator is registered with the given mediatorName.
+	*/
+	ListMediatorInterests(mediatorName string) []string
+
+	/*
+	  List the Notification names that have Observers.
+
+	  - returns: the Notification names, sorted.
+	*/
+	ListNotifications() []string
+
+	/*
+	  List the Observers of a Notification.
+
+	  - parameter notificationName: the name of the INotification
+	  - returns: a copy of the IObserver list, in the order they are notified.
+	*/
+	ListObservers(notificationName string) []IObserver
+
+	/*
+	  Count the Observers of a Notification.
+
+	  - parameter notificationName: the name of the INotification
+	  - returns: the number of IObservers notified of the given notificationName.
+	*/
+	ObserverCount(notificationName string) int
+
 	/*
 		Notify Observers.
 
@@ -0,0 +1,95 @@
+//
+//  IHistory.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+IHistory The interface definition for a PureMVC undo/redo History.
+
+In PureMVC, IHistory implementors assume these responsibilities:
+
+* Record the IUndoableCommands executed within a Core, together with their INotifications.
+
+* Undo and redo recorded steps, where a step is either a single IUndoableCommand or a group of them.
+
+* Notify interested parties when the availability of undo or redo changes.
+*/
+type IHistory interface {
+	/*
+	  Initialize the Multiton History instance.
+	*/
+	InitializeHistory()
+
+	/*
+	  Record an executed IUndoableCommand.
+
+	  - parameter command: the IUndoableCommand that was executed
+	  - parameter notification: the INotification it was executed with
+	*/
+	Record(command IUndoableCommand, notification INotification)
+
+	/*
+	  Capture the scope of an execution about to start: the group open, if any,
+	  or whether a step is being undone or redone.
+
+	  - returns: the func to call exactly once when the execution is over, with the IUndoableCommand to record, or nil.
+	*/
+	Track() func(command IUndoableCommand, notification INotification)
+
+	/*
+	  Undo the most recently recorded step.
+
+	  - returns: whether a step was undone.
+	*/
+	Undo() bool
+
+	/*
+	  Redo the most recently undone step.
+
+	  - returns: whether a step was redone.
+	*/
+	Redo() bool
+
+	/*
+	  Check if there is a step that can be undone.
+	*/
+	CanUndo() bool
+
+	/*
+	  Check if there is a step that can be redone.
+	*/
+	CanRedo() bool
+
+	/*
+	  Begin a group. Every command recorded until the matching
+	  EndGroup is undone and redone as a single step.
+	*/
+	BeginGroup()
+
+	/*
+	  End the group opened by the matching BeginGroup.
+	*/
+	EndGroup()
+
+	/*
+	  End the group opened by the matching BeginGroup, discarding the commands recorded since.
+	*/
+	AbortGroup()
+
+	/*
+	  Set the maximum number of undo steps kept, 0 for no limit.
+
+	  - parameter limit: the maximum number of undo steps
+	*/
+	SetLimit(limit int)
+
+	/*
+	  Discard all undo and redo steps.
+	*/
+	Clear()
+}
@@ -0,0 +1,31 @@
+//
+//  IIdempotentCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+IIdempotentCommand The interface definition for a PureMVC Command
+that must not execute twice for the same INotification.
+
+Before executing an IIdempotentCommand, the IController, or
+an enclosing MacroCommand, claims its key with the IDedupeStore
+of the Core. An execution whose key is already claimed is a
+duplicate: it is skipped and reported. The key is released
+if the execution fails, so a redelivery may try again.
+*/
+type IIdempotentCommand interface {
+	ICommand
+
+	/*
+	  Get the key identifying an execution.
+
+	  - parameter notification: the INotification about to be passed to Execute.
+	  - returns: the key, or an empty string to always execute.
+	*/
+	IdempotencyKey(notification INotification) string
+}
@@ -0,0 +1,23 @@
+//
+//  IInjector.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+IInjector The interface definition for an injector populating
+the dependencies of an ICommand before it is executed.
+*/
+type IInjector interface {
+	/*
+	  Populate the dependencies of a target.
+
+	  - parameter target: a pointer to the struct to populate
+	  - returns: an error if a required dependency could not be resolved.
+	*/
+	Inject(target interface{}) error
+}
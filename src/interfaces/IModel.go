@@ -8,6 +8,11 @@
 
 package interfaces
 
+import (
+	"io"
+	"reflect"
+)
+
 /*
 IModel The interface definition for a PureMVC Model.
 
@@ -34,6 +39,37 @@ type IModel interface {
 	*/
 	RegisterProxy(proxy IProxy)
 
+	/*
+	  Register an IProxy instance with the Model, applying the DuplicatePolicy.
+
+	  - parameter proxy: an object reference to be held by the Model.
+	  - returns: an error under DUPLICATE_ERROR if an IProxy is already registered with the same name.
+	*/
+	TryRegisterProxy(proxy IProxy) error
+
+	/*
+	  Set what happens when an IProxy is registered under a name that is already registered.
+
+	  - parameter policy: the DuplicatePolicy
+	*/
+	SetDuplicatePolicy(policy DuplicatePolicy)
+
+	/*
+	  Register IProxy instances, each IDependentProxy after the Proxies it depends on.
+
+	  - parameter proxies: the IProxy instances to register
+	  - returns: an error if a dependency is missing, the proxies depend on each other in a cycle, or one of them is already registered under DUPLICATE_ERROR, in which case nothing is registered.
+	*/
+	RegisterProxies(proxies ...IProxy) error
+
+	/*
+	  Register a factory of an IProxy, constructed the first time it is retrieved.
+
+	  - parameter proxyName: the name to register the IProxy under
+	  - parameter factory: reference that returns the IProxy
+	*/
+	RegisterLazyProxy(proxyName string, factory func() IProxy)
+
 	/*
 	  Retrieve an IProxy instance from the Model.
 
@@ -50,6 +86,11 @@ type IModel interface {
 	*/
 	RemoveProxy(proxyName string) IProxy
 
+	/*
+	  Remove every IProxy, each IDependentProxy before the Proxies it depends on.
+	*/
+	RemoveAllProxies()
+
 	/*
 	  Check if a Proxy is registered
 
@@ -57,4 +98,52 @@ type IModel interface {
 	  - returns: whether a Proxy is currently registered with the given proxyName.
 	*/
 	HasProxy(proxyName string) bool
+
+	/*
+	  List the names of the registered Proxies.
+
+	  - returns: the Proxy names, sorted.
+	*/
+	ListProxies() []string
+
+	/*
+	  Count the registered Proxies.
+
+	  - returns: the number of registered Proxies.
+	*/
+	ProxyCount() int
+
+	/*
+	  Find the Proxies matching a predicate.
+
+	  - parameter predicate: called with each IProxy, outside any lock
+	  - returns: the matching IProxy instances, sorted by name.
+	*/
+	FindProxies(predicate func(proxy IProxy) bool) []IProxy
+
+	/*
+	  Find the Proxies of a Go type, or implementing an interface type.
+
+	  - parameter _type: the concrete type, e.g. reflect.TypeOf(&UserProxy{}), or the interface type, e.g. reflect.TypeOf((*io.Closer)(nil)).Elem()
+	  - returns: the matching IProxy instances, sorted by name.
+	*/
+	FindProxiesOfType(_type reflect.Type) []IProxy
+
+	/*
+	  Save the state of every registered ISnapshotProxy.
+
+	  - parameter writer: where to write the snapshot
+	  - parameter format: the encoding of the snapshot
+	  - returns: an error naming the IProxy whose state could not be saved.
+	*/
+	SaveSnapshot(writer io.Writer, format SnapshotFormat) error
+
+	/*
+	  Restore the state of the registered ISnapshotProxy instances from a snapshot.
+
+	  - parameter reader: where to read the snapshot from
+	  - parameter format: the encoding of the snapshot
+	  - returns: an error naming the IProxy whose state could not be restored.
+	*/
+	RestoreSnapshot(reader io.Reader, format SnapshotFormat) error
 }
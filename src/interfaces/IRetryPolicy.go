@@ -0,0 +1,29 @@
+//
+//  IRetryPolicy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+import "time"
+
+/*
+IRetryPolicy The interface definition for a policy deciding
+whether, and when, a failed ICommand execution is retried.
+
+Each attempt creates a new ICommand instance with the factory
+of the mapping, so no state is carried between attempts.
+*/
+type IRetryPolicy interface {
+	/*
+	  Decide whether a failed attempt is retried.
+
+	  - parameter attempt: the number of the failed attempt, starting at 1
+	  - parameter err: the error the attempt failed with
+	  - returns: the delay before the next attempt, and whether there is one.
+	*/
+	NextDelay(attempt int, err error) (time.Duration, bool)
+}
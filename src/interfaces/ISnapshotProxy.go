@@ -0,0 +1,42 @@
+//
+//  ISnapshotProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+ISnapshotProxy The interface definition for a PureMVC Proxy
+whose state is saved in, and restored from, snapshots of its IModel.
+*/
+type ISnapshotProxy interface {
+	IProxy
+
+	/*
+	  Get the state to save.
+
+	  - returns: a value the snapshot format can encode, or an error.
+	*/
+	Snapshot() (interface{}, error)
+
+	/*
+	  Restore a saved state.
+
+	  - parameter decode: decodes the saved state into the value it is passed a pointer to
+	  - returns: an error if the state could not be decoded or applied.
+	*/
+	Restore(decode func(target interface{}) error) error
+}
+
+/*
+SnapshotFormat The encoding of a snapshot of an IModel.
+*/
+type SnapshotFormat int
+
+const (
+	SNAPSHOT_JSON SnapshotFormat = iota // a JSON object with a member per proxy name
+	SNAPSHOT_GOB                        // a gob encoded map of proxy names to gob encoded states
+)
@@ -0,0 +1,28 @@
+//
+//  IUndoableCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package interfaces
+
+/*
+IUndoableCommand The interface definition for a reversible PureMVC Command.
+
+An IUndoableCommand executed by the IController is recorded
+with the IHistory of its Core, so that its effect can later
+be reversed by calling Undo, and reapplied by calling Execute
+again with the same INotification.
+*/
+type IUndoableCommand interface {
+	ICommand
+
+	/*
+	  Reverse the effect of a previous call to Execute.
+
+	  - parameter notification: the INotification originally passed to Execute.
+	*/
+	Undo(notification INotification)
+}
@@ -79,6 +79,21 @@ type IView interface {
 	*/
 	RegisterMediator(mediator IMediator)
 
+	/*
+	  Register an IMediator instance with the View, applying the DuplicatePolicy.
+
+	  - parameter mediator: a reference to the IMediator instance
+	  - returns: an error under DUPLICATE_ERROR if an IMediator is already registered with the same name.
+	*/
+	TryRegisterMediator(mediator IMediator) error
+
+	/*
+	  Set what happens when an IMediator is registered under a name that is already registered.
+
+	  - parameter policy: the DuplicatePolicy
+	*/
+	SetDuplicatePolicy(policy DuplicatePolicy)
+
 	/*
 	  Retrieve an IMediator from the View.
 
@@ -102,4 +117,49 @@ type IView interface {
 	  - returns: whether a Mediator is registered with the given mediatorName.
 	*/
 	HasMediator(mediatorName string) bool
+
+	/*
+	  List the names of the registered Mediators.
+
+	  - returns: the Mediator names, sorted.
+	*/
+	ListMediators() []string
+
+	/*
+	  Count the registered Mediators.
+
+	  - returns: the number of registered Mediators.
+	*/
+	MediatorCount() int
+
+	/*
+	  List the Notification names a Mediator is observing.
+
+	  - parameter mediatorName: the name of the IMediator
+	  - returns: the Notification names, sorted, nil if no Mediator is registered with the given mediatorName.
+	*/
+	ListMediatorInterests(mediatorName string) []string
+
+	/*
+	  List the Notification names that have Observers.
+
+	  - returns: the Notification names, sorted.
+	*/
+	ListNotifications() []string
+
+	/*
+	  List the Observers of a Notification.
+
+	  - parameter notificationName: the name of the INotification
+	  - returns: a copy of the IObserver list, in the order they are notified.
+	*/
+	ListObservers(notificationName string) []IObserver
+
+	/*
+	  Count the Observers of a Notification.
+
+	  - parameter notificationName: the name of the INotification
+	  - returns: the number of IObservers notified of the given notificationName.
+	*/
+	ObserverCount(notificationName string) int
 }
@@ -9,6 +9,10 @@
 package command
 
 import (
+	"context"
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/history"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
 )
@@ -34,6 +38,7 @@ to be executed.
 */
 type MacroCommand struct {
 	facade.Notifier
+	facade.CommandContext
 	SubCommands []func() interfaces.ICommand
 }
 
@@ -77,16 +82,138 @@ Execute this MacroCommand's SubCommands.
 The SubCommands will be called in First In/First Out (FIFO)
 order.
 
+SubCommands that are IUndoableCommands are recorded with the
+IHistory of the Core as a single group, so they are undone
+and redone together as one step. The group is discarded if
+the MacroCommand fails or panics, so no partial step is recorded.
+
+SubCommands have their dependencies injected by the IInjector of
+the Controller of the Core, if any, and the MacroCommand fails if
+they cannot be injected.
+
+SubCommands that are IIdempotentCommands are claimed with the
+Controller of the Core, and skipped when they are duplicates.
+
+SubCommands that are IContextCommands share the context.Context
+of the MacroCommand. If a SubCommand fails, or the context.Context
+is done before the next SubCommand, the remaining SubCommands are
+skipped and the MacroCommand fails with the same error.
+
 - parameter notification: the INotification object to be passsed to each SubCommand.
 */
 func (self *MacroCommand) Execute(notification interfaces.INotification) {
 	self.InitializeMacroCommand()
+
+	var h = history.GetInstance(self.Key, func() interfaces.IHistory { return &history.History{Key: self.Key} })
+	var completed = false
+	h.BeginGroup()
+	defer func() {
+		if completed {
+			h.EndGroup()
+		} else {
+			h.AbortGroup()
+		}
+	}()
+
 	for len(self.SubCommands) > 0 {
+		if err := self.Context().Err(); err != nil {
+			self.SubCommands = nil
+			self.Fail(err)
+			return
+		}
+
 		factory := self.SubCommands[0]
 		self.SubCommands = self.SubCommands[1:]
 
 		commandInstance := factory()
 		commandInstance.InitializeNotifier(self.Key)
-		commandInstance.Execute(notification)
+		if err := self.inject(commandInstance); err != nil {
+			self.SubCommands = nil
+			self.Fail(err)
+			return
+		}
+		done, claimed := self.claim(commandInstance, notification)
+		if claimed == false {
+			continue
+		}
+		if err := executeSubCommand(self.Context(), commandInstance, notification, done); err != nil {
+			self.SubCommands = nil
+			self.Fail(err)
+			return
+		}
+
+		if undoable, ok := commandInstance.(interfaces.IUndoableCommand); ok {
+			h.Record(undoable, notification)
+		}
+	}
+	completed = true
+}
+
+/*
+executionClaimer The part of the Controller claiming IIdempotentCommand executions.
+*/
+type executionClaimer interface {
+	ClaimExecution(command interfaces.ICommand, notification interfaces.INotification) (func(err error), bool)
+}
+
+/*
+commandInjector The part of the Controller injecting the dependencies of ICommands.
+*/
+type commandInjector interface {
+	InjectCommand(command interfaces.ICommand) error
+}
+
+var errPanicked = errors.New("sub command panicked") // passed to the done func of a SubCommand that panicked
+
+/*
+inject Inject the dependencies of a SubCommand with the Controller of the Core.
+
+- returns: the error of the IInjector of the Controller, if any.
+*/
+func (self *MacroCommand) inject(command interfaces.ICommand) error {
+	var c = controller.GetInstance(self.Key, func() interfaces.IController { return &controller.Controller{Key: self.Key} })
+	if injector, ok := c.(commandInjector); ok {
+		return injector.InjectCommand(command)
+	}
+	return nil
+}
+
+/*
+claim Claim the execution of a SubCommand with the Controller of the Core.
+
+- returns: the func to call with the result of the SubCommand, and whether it was claimed.
+*/
+func (self *MacroCommand) claim(command interfaces.ICommand, notification interfaces.INotification) (func(err error), bool) {
+	var c = controller.GetInstance(self.Key, func() interfaces.IController { return &controller.Controller{Key: self.Key} })
+	if claimer, ok := c.(executionClaimer); ok {
+		return claimer.ClaimExecution(command, notification)
+	}
+	return func(err error) {}, true
+}
+
+/*
+executeSubCommand Execute a claimed SubCommand within a context.Context.
+
+The done func is called with the result, or errPanicked if the SubCommand panics.
+
+- returns: the error the SubCommand failed with, if any.
+*/
+func executeSubCommand(ctx context.Context, command interfaces.ICommand, notification interfaces.INotification, done func(err error)) (err error) {
+	var returned = false
+	defer func() {
+		if returned == false {
+			done(errPanicked)
+		}
+	}()
+
+	if contextCommand, ok := command.(interfaces.IContextCommand); ok {
+		contextCommand.InitializeContext(ctx)
+		command.Execute(notification)
+		err = contextCommand.Err()
+	} else {
+		command.Execute(notification)
 	}
+	returned = true
+	done(err)
+	return err
 }
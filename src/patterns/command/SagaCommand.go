@@ -0,0 +1,168 @@
+//
+//  SagaCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+)
+
+const SAGA_RESULT = "SagaResult"      // sent when a SagaCommand finishes, the body is a *SagaResult
+const SAGA_COMMITTED = "committed"    // type of a SAGA_RESULT notification when every step completed
+const SAGA_ROLLED_BACK = "rolledBack" // type of a SAGA_RESULT notification when a step failed
+
+var errCompensated = errors.New("saga step compensated") // passed to the done func of a completed step once it is compensated
+
+/*
+SagaResult The body of a SAGA_RESULT notification.
+*/
+type SagaResult struct {
+	Notification       interfaces.INotification // the INotification the SagaCommand was executed with
+	Committed          bool                     // whether every step completed
+	FailedStep         int                      // index of the failing step, -1 when committed
+	FailedCommand      interfaces.ICommand      // the failing step, nil when committed
+	Err                error                    // why the step failed, nil when committed
+	Compensated        int                      // number of completed steps whose Compensate was called
+	CompensationErrors []error                  // failures of compensating actions, if any
+}
+
+/*
+SagaCommand A MacroCommand variant that rolls back on failure.
+
+Like MacroCommand, a SagaCommand executes its SubCommands
+in First In/First Out (FIFO) order, each with the original
+INotification. A SubCommand fails when its dependencies cannot
+be injected, when its Execute panics,
+when it is an IContextCommand that reports an error, or when
+the context.Context of the SagaCommand is done before it runs.
+A step skipped as a duplicate neither fails nor is compensated.
+
+When a step fails, the remaining steps are skipped and every
+step that has already completed and is an ICompensableCommand
+has its Compensate method called, in reverse order. A compensated
+step releases its idempotency key, so a redelivered SagaCommand
+executes it again.
+
+In either case a SAGA_RESULT notification is sent with a
+*SagaResult body, its type being SAGA_COMMITTED or SAGA_ROLLED_BACK.
+A rolled back SagaCommand also fails with the error of the failing step.
+
+Add SubCommands the same way as with MacroCommand:
+
+	func (self *PlaceOrderCommand) Execute(notification interfaces.INotification) {
+	  self.AddSubCommand(func() interfaces.ICommand { return &ReserveStockCommand{} })
+	  self.AddSubCommand(func() interfaces.ICommand { return &ChargePaymentCommand{} })
+	  self.AddSubCommand(func() interfaces.ICommand { return &ShipOrderCommand{} })
+	  self.SagaCommand.Execute(notification)
+	}
+*/
+type SagaCommand struct {
+	MacroCommand
+}
+
+/*
+Execute this SagaCommand's SubCommands, compensating
+the completed ones if a later SubCommand fails.
+
+- parameter notification: the INotification object to be passsed to each SubCommand.
+*/
+func (self *SagaCommand) Execute(notification interfaces.INotification) {
+	self.InitializeMacroCommand()
+
+	var result = &SagaResult{Notification: notification, Committed: true, FailedStep: -1}
+	var completed []interfaces.ICommand
+	var releases []func(err error)
+
+	for step := 0; len(self.SubCommands) > 0; step++ {
+		factory := self.SubCommands[0]
+		self.SubCommands = self.SubCommands[1:]
+
+		commandInstance := factory()
+		commandInstance.InitializeNotifier(self.Key)
+		var done func(err error)
+		var err = self.inject(commandInstance)
+		if err == nil {
+			var claimed bool
+			done, claimed = self.claim(commandInstance, notification)
+			if claimed == false {
+				continue
+			}
+			err = self.executeStep(commandInstance, notification, done)
+		}
+		if err != nil {
+			result.Committed = false
+			result.FailedStep = step
+			result.FailedCommand = commandInstance
+			result.Err = err
+			break
+		}
+		completed = append(completed, commandInstance)
+		releases = append(releases, done)
+	}
+	self.SubCommands = nil
+
+	var _type = SAGA_COMMITTED
+	if result.Committed == false {
+		_type = SAGA_ROLLED_BACK
+		for i := len(completed) - 1; i >= 0; i-- {
+			if compensable, ok := completed[i].(interfaces.ICompensableCommand); ok {
+				if err := self.compensateStep(compensable, notification); err != nil {
+					result.CompensationErrors = append(result.CompensationErrors, err)
+				}
+				releases[i](errCompensated)
+				result.Compensated++
+			}
+		}
+		self.Fail(result.Err)
+	}
+
+	self.SendNotification(SAGA_RESULT, result, _type)
+}
+
+/*
+executeStep Execute a step, recovering a panic as its failure.
+*/
+func (self *SagaCommand) executeStep(command interfaces.ICommand, notification interfaces.INotification, done func(err error)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	if err := self.Context().Err(); err != nil {
+		done(err)
+		return err
+	}
+	return executeSubCommand(self.Context(), command, notification, done)
+}
+
+/*
+compensateStep Compensate a step, recovering a panic as its failure.
+*/
+func (self *SagaCommand) compensateStep(command interfaces.ICompensableCommand, notification interfaces.INotification) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = toError(r)
+		}
+	}()
+
+	command.Compensate(notification)
+	return nil
+}
+
+/*
+toError Convert a recovered panic value to an error.
+*/
+func toError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
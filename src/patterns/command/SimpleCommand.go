@@ -18,9 +18,14 @@ SimpleCommand A base ICommand implementation.
 
 Your subclass should override the execute
 method where your business logic will handle the INotification.
+
+SimpleCommand is an IContextCommand: Execute may consult
+Context to honor cancellation and timeouts, and call Fail
+to report that it could not fulfill the use-case.
 */
 type SimpleCommand struct {
 	facade.Notifier
+	facade.CommandContext
 }
 
 /*
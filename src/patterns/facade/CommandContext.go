@@ -0,0 +1,64 @@
+//
+//  CommandContext.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package facade
+
+import "context"
+
+/*
+CommandContext The context.Context and error methods of IContextCommand.
+
+SimpleCommand, MacroCommand and FuncCommand embed it
+to hold the context.Context of their execution and the
+error it failed with.
+*/
+type CommandContext struct {
+	ctx context.Context // The context.Context of this execution
+	err error           // The error this execution failed with
+}
+
+/*
+InitializeContext Initialize the context.Context of this execution.
+
+Called by the Controller or an enclosing MacroCommand before Execute.
+
+- parameter ctx: the context.Context of this execution
+*/
+func (self *CommandContext) InitializeContext(ctx context.Context) {
+	self.ctx = ctx
+}
+
+/*
+Context Get the context.Context of this execution.
+
+- returns: the context.Context, context.Background() if none was initialized.
+*/
+func (self *CommandContext) Context() context.Context {
+	if self.ctx == nil {
+		return context.Background()
+	}
+	return self.ctx
+}
+
+/*
+Fail Report that this execution failed.
+
+- parameter err: why the execution failed
+*/
+func (self *CommandContext) Fail(err error) {
+	self.err = err
+}
+
+/*
+Err Get the error this execution failed with.
+
+- returns: the error passed to Fail, nil if Execute succeeded.
+*/
+func (self *CommandContext) Err() error {
+	return self.err
+}
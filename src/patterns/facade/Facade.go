@@ -10,10 +10,13 @@ package facade
 
 import (
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/history"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/model"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"io"
+	"reflect"
 	"sync"
 )
 
@@ -141,9 +144,28 @@ RegisterCommand Register an ICommand with the Controller by Notification name.
 - parameter notificationName: the name of the INotification to associate the ICommand with
 
 - parameter factory: reference that returns ICommand
+
+- parameter options: CommandOption funcs applied to the mapping (optional)
+*/
+func (self *Facade) RegisterCommand(notificationName string, factory func() interfaces.ICommand, options ...interfaces.CommandOption) {
+	self.controller.RegisterCommand(notificationName, factory, options...)
+}
+
+/*
+RegisterCommandFunc Register a CommandFunc with the Controller by Notification name.
+
+The CommandFunc is wrapped in a new FuncCommand for each
+execution, so it is treated like any other ICommand and
+removed with RemoveCommand.
+
+- parameter notificationName: the name of the INotification to associate the CommandFunc with
+
+- parameter handler: the CommandFunc
+
+- parameter options: CommandOption funcs applied to the mapping (optional)
 */
-func (self *Facade) RegisterCommand(notificationName string, factory func() interfaces.ICommand) {
-	self.controller.RegisterCommand(notificationName, factory)
+func (self *Facade) RegisterCommandFunc(notificationName string, handler interfaces.CommandFunc, options ...interfaces.CommandOption) {
+	self.RegisterCommand(notificationName, func() interfaces.ICommand { return &FuncCommand{Handler: handler} }, options...)
 }
 
 /*
@@ -166,6 +188,24 @@ func (self *Facade) HasCommand(notificationName string) bool {
 	return self.controller.HasCommand(notificationName)
 }
 
+/*
+ListCommands List the Notification names Commands are registered for.
+
+- returns: the Notification names, sorted.
+*/
+func (self *Facade) ListCommands() []string {
+	return self.controller.ListCommands()
+}
+
+/*
+CommandCount Count the registered Commands.
+
+- returns: the number of Notification names Commands are registered for.
+*/
+func (self *Facade) CommandCount() int {
+	return self.controller.CommandCount()
+}
+
 /*
 RegisterProxy Register an IProxy with the Model by name.
 
@@ -175,6 +215,43 @@ func (self *Facade) RegisterProxy(proxy interfaces.IProxy) {
 	self.model.RegisterProxy(proxy)
 }
 
+/*
+TryRegisterProxy Register an IProxy with the Model by name, applying the DuplicatePolicy.
+
+- parameter proxy: the IProxy instance to be registered with the Model.
+
+- returns: an error wrapping model.ErrDuplicateProxy under DUPLICATE_ERROR if the name is already registered.
+*/
+func (self *Facade) TryRegisterProxy(proxy interfaces.IProxy) error {
+	return self.model.TryRegisterProxy(proxy)
+}
+
+/*
+RegisterProxies Register IProxy instances with the Model, each
+IDependentProxy after the Proxies it depends on.
+
+- parameter proxies: the IProxy instances to register
+
+- returns: an error if a dependency is missing, the proxies depend on each other in a cycle, or one of them is already registered under DUPLICATE_ERROR, in which case nothing is registered.
+*/
+func (self *Facade) RegisterProxies(proxies ...interfaces.IProxy) error {
+	return self.model.RegisterProxies(proxies...)
+}
+
+/*
+RegisterLazyProxy Register a factory of an IProxy with the Model.
+
+The IProxy is constructed, initialized and registered the
+first time it is retrieved.
+
+- parameter proxyName: the name to register the IProxy under
+
+- parameter factory: reference that returns the IProxy
+*/
+func (self *Facade) RegisterLazyProxy(proxyName string, factory func() interfaces.IProxy) {
+	self.model.RegisterLazyProxy(proxyName, factory)
+}
+
 /*
 RetrieveProxy Retrieve an IProxy from the Model by name.
 
@@ -197,6 +274,14 @@ func (self *Facade) RemoveProxy(proxyName string) interfaces.IProxy {
 	return self.model.RemoveProxy(proxyName)
 }
 
+/*
+RemoveAllProxies Remove every IProxy from the Model, each
+IDependentProxy before the Proxies it depends on.
+*/
+func (self *Facade) RemoveAllProxies() {
+	self.model.RemoveAllProxies()
+}
+
 /*
 HasProxy Check if a Proxy is registered
 
@@ -208,6 +293,72 @@ func (self *Facade) HasProxy(proxyName string) bool {
 	return self.model.HasProxy(proxyName)
 }
 
+/*
+ListProxies List the names of the registered Proxies.
+
+- returns: the Proxy names, sorted.
+*/
+func (self *Facade) ListProxies() []string {
+	return self.model.ListProxies()
+}
+
+/*
+ProxyCount Count the registered Proxies.
+
+- returns: the number of registered Proxies.
+*/
+func (self *Facade) ProxyCount() int {
+	return self.model.ProxyCount()
+}
+
+/*
+FindProxies Find the Proxies matching a predicate.
+
+- parameter predicate: called with each IProxy, outside any lock
+
+- returns: the matching IProxy instances, sorted by name.
+*/
+func (self *Facade) FindProxies(predicate func(proxy interfaces.IProxy) bool) []interfaces.IProxy {
+	return self.model.FindProxies(predicate)
+}
+
+/*
+FindProxiesOfType Find the Proxies of a Go type, or implementing an interface type.
+
+- parameter _type: the concrete type of the IProxy, or an interface type it implements
+
+- returns: the matching IProxy instances, sorted by name.
+*/
+func (self *Facade) FindProxiesOfType(_type reflect.Type) []interfaces.IProxy {
+	return self.model.FindProxiesOfType(_type)
+}
+
+/*
+SaveSnapshot Save the state of every registered ISnapshotProxy.
+
+- parameter writer: where to write the snapshot
+
+- parameter format: the encoding of the snapshot
+
+- returns: an error naming the IProxy whose state could not be saved.
+*/
+func (self *Facade) SaveSnapshot(writer io.Writer, format interfaces.SnapshotFormat) error {
+	return self.model.SaveSnapshot(writer, format)
+}
+
+/*
+RestoreSnapshot Restore the state of the registered ISnapshotProxy instances from a snapshot.
+
+- parameter reader: where to read the snapshot from
+
+- parameter format: the encoding of the snapshot
+
+- returns: an error naming the IProxy whose state could not be restored.
+*/
+func (self *Facade) RestoreSnapshot(reader io.Reader, format interfaces.SnapshotFormat) error {
+	return self.model.RestoreSnapshot(reader, format)
+}
+
 /*
 RegisterMediator Register a IMediator with the View.
 
@@ -217,6 +368,28 @@ func (self *Facade) RegisterMediator(mediator interfaces.IMediator) {
 	self.view.RegisterMediator(mediator)
 }
 
+/*
+TryRegisterMediator Register a IMediator with the View, applying the DuplicatePolicy.
+
+- parameter mediator: a reference to the IMediator
+
+- returns: an error wrapping view.ErrDuplicateMediator under DUPLICATE_ERROR if the name is already registered.
+*/
+func (self *Facade) TryRegisterMediator(mediator interfaces.IMediator) error {
+	return self.view.TryRegisterMediator(mediator)
+}
+
+/*
+SetDuplicatePolicy Set what happens when an IProxy or IMediator
+is registered under a name that is already registered in this Core.
+
+- parameter policy: the DuplicatePolicy of both the Model and the View
+*/
+func (self *Facade) SetDuplicatePolicy(policy interfaces.DuplicatePolicy) {
+	self.model.SetDuplicatePolicy(policy)
+	self.view.SetDuplicatePolicy(policy)
+}
+
 /*
 RetrieveMediator Retrieve an IMediator from the View.
 
@@ -250,6 +423,66 @@ func (self *Facade) HasMediator(mediatorName string) bool {
 	return self.view.HasMediator(mediatorName)
 }
 
+/*
+ListMediators List the names of the registered Mediators.
+
+- returns: the Mediator names, sorted.
+*/
+func (self *Facade) ListMediators() []string {
+	return self.view.ListMediators()
+}
+
+/*
+MediatorCount Count the registered Mediators.
+
+- returns: the number of registered Mediators.
+*/
+func (self *Facade) MediatorCount() int {
+	return self.view.MediatorCount()
+}
+
+/*
+ListMediatorInterests List the Notification names a Mediator is observing.
+
+- parameter mediatorName: the name of the IMediator
+
+- returns: the Notification names, sorted, nil if no Mediator is registered with the given mediatorName.
+*/
+func (self *Facade) ListMediatorInterests(mediatorName string) []string {
+	return self.view.ListMediatorInterests(mediatorName)
+}
+
+/*
+ListNotifications List the Notification names that have Observers.
+
+- returns: the Notification names, sorted.
+*/
+func (self *Facade) ListNotifications() []string {
+	return self.view.ListNotifications()
+}
+
+/*
+ListObservers List the Observers of a Notification.
+
+- parameter notificationName: the name of the INotification
+
+- returns: a copy of the IObserver list, in the order they are notified.
+*/
+func (self *Facade) ListObservers(notificationName string) []interfaces.IObserver {
+	return self.view.ListObservers(notificationName)
+}
+
+/*
+ObserverCount Count the Observers of a Notification.
+
+- parameter notificationName: the name of the INotification
+
+- returns: the number of IObservers notified of the given notificationName.
+*/
+func (self *Facade) ObserverCount(notificationName string) int {
+	return self.view.ObserverCount(notificationName)
+}
+
 /*
 SendNotification Create and send an INotification.
 
@@ -310,17 +543,23 @@ func HasCore(key string) bool {
 /*
 RemoveCore Remove a Core.
 
-Remove the Model, View, Controller and Facade
+Remove the Model, View, Controller, History and Facade
 instances for the given key.
 
+The Model is removed first, without holding any lock, so
+the OnRemove of its IProxys can still send notifications
+handled by the Commands of the Core.
+
 - parameter key: multitonKey of the Core to remove
 */
 func RemoveCore(key string) {
+	model.RemoveModel(key)
+
 	instanceMapMutex.Lock()
 	defer instanceMapMutex.Unlock()
 
-	model.RemoveModel(key)
 	view.RemoveView(key)
 	controller.RemoveController(key)
+	history.RemoveHistory(key)
 	delete(instanceMap, key)
 }
@@ -0,0 +1,38 @@
+//
+//  FuncCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package facade
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+
+/*
+FuncCommand An IContextCommand calling a CommandFunc.
+
+Created by Facade.RegisterCommandFunc for each execution,
+it lets a plain func handle an INotification without
+defining a struct that embeds SimpleCommand:
+
+	facade.RegisterCommandFunc(LOGIN, func(ctx context.Context, notification interfaces.INotification, facade interfaces.IFacade) error {
+	  var user = facade.RetrieveProxy(UserProxyName).(*UserProxy)
+	  return user.Login(ctx, notification.Body().(*Credentials))
+	})
+*/
+type FuncCommand struct {
+	Notifier
+	CommandContext
+	Handler interfaces.CommandFunc // the func called by Execute
+}
+
+/*
+Execute Call the Handler with the context.Context, the INotification and the IFacade.
+
+- parameter notification: the INotification to handle
+*/
+func (self *FuncCommand) Execute(notification interfaces.INotification) {
+	self.Fail(self.Handler(self.Context(), notification, self.Facade))
+}
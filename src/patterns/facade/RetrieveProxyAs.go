@@ -0,0 +1,67 @@
+//
+//  RetrieveProxyAs.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"reflect"
+)
+
+var ErrProxyNotFound = errors.New("proxy not found")       // no IProxy is registered with the requested name
+var ErrProxyType = errors.New("proxy has unexpected type") // the IProxy registered with the requested name is not of the requested type
+
+/*
+RetrieveProxyAs Retrieve an IProxy by name, as a given type.
+
+	users, err := facade.RetrieveProxyAs[*UserProxy](self.Facade, UserProxyName)
+
+- parameter facade: the IFacade, or IModel, to retrieve the IProxy from
+
+- parameter proxyName: the name of the IProxy
+
+- returns: the IProxy as a T, or an error naming the IProxy and wrapping ErrProxyNotFound or ErrProxyType.
+*/
+func RetrieveProxyAs[T interfaces.IProxy](facade interface {
+	RetrieveProxy(proxyName string) interfaces.IProxy
+}, proxyName string) (T, error) {
+	var zero T
+	var proxy = facade.RetrieveProxy(proxyName)
+	if proxy == nil {
+		return zero, fmt.Errorf("%w: %q", ErrProxyNotFound, proxyName)
+	}
+
+	typed, ok := proxy.(T)
+	if ok == false {
+		return zero, fmt.Errorf("%w: %q is %T, not %v", ErrProxyType, proxyName, proxy, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return typed, nil
+}
+
+/*
+MustRetrieve Retrieve an IProxy by name, as a given type, or panic.
+
+	var users = facade.MustRetrieve[*UserProxy](self.Facade, UserProxyName)
+
+- parameter facade: the IFacade, or IModel, to retrieve the IProxy from
+
+- parameter proxyName: the name of the IProxy
+
+- returns: the IProxy as a T. Panics with the error of RetrieveProxyAs if it is missing or of another type.
+*/
+func MustRetrieve[T interfaces.IProxy](facade interface {
+	RetrieveProxy(proxyName string) interfaces.IProxy
+}, proxyName string) T {
+	typed, err := RetrieveProxyAs[T](facade, proxyName)
+	if err != nil {
+		panic(err)
+	}
+	return typed
+}
@@ -0,0 +1,79 @@
+//
+//  BaseProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+)
+
+/*
+BaseProxy The part shared by the generic IProxy implementations
+of this package, embedded by each of them.
+
+It holds the name of the proxy, and only sends notifications once
+its notifier is initialized, which the Model does when the proxy is
+registered, so a proxy that was never registered stays silent.
+*/
+type BaseProxy struct {
+	facade.Notifier
+	Name string // the proxy name
+}
+
+/*
+GetProxyName Get the proxy name
+*/
+func (self *BaseProxy) GetProxyName() string {
+	return self.Name
+}
+
+/*
+OnRegister Called by the Model when the Proxy is registered
+*/
+func (self *BaseProxy) OnRegister() {
+
+}
+
+/*
+OnRemove Called by the Model when the Proxy is removed
+*/
+func (self *BaseProxy) OnRemove() {
+
+}
+
+/*
+notifying Check if a notification with a name is configured and can be sent.
+*/
+func (self *BaseProxy) notifying(name string) bool {
+	return name != "" && self.Facade != nil
+}
+
+/*
+notify Send a notification with the name of the proxy as its type,
+if the name of the notification is configured and the notifier is initialized.
+*/
+func (self *BaseProxy) notify(name string, body interface{}) {
+	if self.notifying(name) {
+		self.SendNotification(name, body, self.Name)
+	}
+}
+
+/*
+assertData Convert the data passed to SetData to the type held by a proxy.
+
+Panics if the data is not a T.
+*/
+func assertData[T any](self *BaseProxy, data interface{}) T {
+	typed, ok := data.(T)
+	if ok == false {
+		var zero T
+		panic(fmt.Sprintf("proxy %q holds %T, cannot set %T", self.Name, zero, data))
+	}
+	return typed
+}
@@ -0,0 +1,431 @@
+//
+//  CachingProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"sync"
+	"time"
+)
+
+/*
+CachingProxy A generic IProxy implementation caching the values
+of type V loaded by key.
+
+Get returns the cached value of a key, or calls Loader on a miss
+and caches the value it returns. Values expire once their TTL has
+passed, and the least recently used values are evicted once there
+are more than MaxSize of them:
+
+	var prices = &proxy.CachingProxy[string, float64]{
+		BaseProxy:               proxy.BaseProxy{Name: PriceProxyName},
+		Loader:                  client.FetchPrice,
+		TTL:                     time.Minute,
+		MaxSize:                 1000,
+		InvalidateNotifications: []string{PRICES_CHANGED},
+	}
+	price, err := prices.Get(ctx, "EUR")
+
+Concurrent misses of the same key share a single call to Loader,
+which runs on its own goroutine. Its context.Context is cancelled
+once every Get waiting for it has stopped because its own
+context.Context is done.
+
+Once registered, the notifications named in InvalidateNotifications
+invalidate the key in their body if it is a K, the keys if it is
+a []K, and every value otherwise.
+
+OnHit, OnMiss and OnEvict, when set, are called without holding
+any lock for each hit, miss and eviction, to record metrics.
+*/
+type CachingProxy[K comparable, V any] struct {
+	BaseProxy
+	Loader                  func(ctx context.Context, key K) (V, error) // loads the value of a key on a miss
+	TTL                     time.Duration                               // how long a value is cached, 0 for no expiry
+	MaxSize                 int                                         // maximum number of values cached, 0 for no limit
+	InvalidateNotifications []string                                    // names of the notifications invalidating cached values
+	OnHit                   func(key K)                                 // called when the value of a key is cached
+	OnMiss                  func(key K)                                 // called when the value of a key is loaded
+	OnEvict                 func(key K, reason EvictReason)             // called when the value of a key is evicted
+	entries                 map[K]*list.Element                         // Mapping of keys to elements of order
+	order                   *list.List                                  // cached values, most recently used first
+	loads                   map[K]*cacheLoad[V]                         // Mapping of keys to the loads in progress
+	mutex                   sync.Mutex                                  // Mutex for entries, order and loads
+}
+
+/*
+cacheEntry A cached value and when it expires.
+*/
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+/*
+cacheLoad A call to Loader, shared by the misses waiting for it.
+*/
+type cacheLoad[V any] struct {
+	done        chan struct{}      // closed once the load is over
+	cancel      context.CancelFunc // cancels the context.Context of Loader
+	waiters     int                // number of Get calls waiting for the load
+	value       V                  // the loaded value, set before done is closed
+	err         error              // the error of the load, set before done is closed
+	invalidated bool               // whether the key was invalidated or put meanwhile, so the value is not cached
+}
+
+/*
+cacheEviction An evicted key, reported once the lock is released.
+*/
+type cacheEviction[K comparable] struct {
+	key    K
+	reason EvictReason
+}
+
+/*
+Get Get the value of a key, loading it on a miss.
+
+- parameter ctx: stops waiting for the load, which is cancelled once no call waits for it
+- parameter key: the key of the value
+
+- returns: the value, or the error of Loader or of the context.Context, in which case nothing is cached.
+*/
+func (self *CachingProxy[K, V]) Get(ctx context.Context, key K) (V, error) {
+	self.mutex.Lock()
+	self.initialize()
+
+	var evictions []cacheEviction[K]
+	if element, ok := self.entries[key]; ok {
+		if self.expired(element.Value.(*cacheEntry[K, V]), time.Now()) == false {
+			self.order.MoveToFront(element)
+			var value = element.Value.(*cacheEntry[K, V]).value
+			self.mutex.Unlock()
+
+			self.hit(key)
+			return value, nil
+		}
+		evictions = append(evictions, self.remove(element, EVICT_EXPIRED))
+	}
+
+	load, loading := self.loads[key]
+	var loadCtx context.Context
+	if loading == false {
+		load = &cacheLoad[V]{done: make(chan struct{})}
+		loadCtx, load.cancel = context.WithCancel(context.Background())
+		self.loads[key] = load
+	}
+	load.waiters++
+	self.mutex.Unlock()
+
+	self.evict(evictions)
+	self.miss(key)
+
+	if loading == false {
+		go self.load(loadCtx, key, load)
+	}
+
+	select {
+	case <-load.done:
+		return load.value, load.err
+	case <-ctx.Done():
+		self.leave(key, load)
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+/*
+Put Cache the value of a key without loading it,
+and discard the value of the load in progress for the key, if any.
+
+- parameter key: the key of the value
+- parameter value: the value
+*/
+func (self *CachingProxy[K, V]) Put(key K, value V) {
+	self.mutex.Lock()
+	self.initialize()
+	var evictions = self.put(key, value)
+	if load, ok := self.loads[key]; ok {
+		load.invalidated = true
+	}
+	self.mutex.Unlock()
+
+	self.evict(evictions)
+}
+
+/*
+Invalidate Evict the value of a key, and discard the value
+of the load in progress for the key, if any.
+
+- parameter key: the key of the value
+*/
+func (self *CachingProxy[K, V]) Invalidate(key K) {
+	self.mutex.Lock()
+	self.initialize()
+	var evictions []cacheEviction[K]
+	if element, ok := self.entries[key]; ok {
+		evictions = append(evictions, self.remove(element, EVICT_INVALIDATED))
+	}
+	if load, ok := self.loads[key]; ok {
+		load.invalidated = true
+	}
+	self.mutex.Unlock()
+
+	self.evict(evictions)
+}
+
+/*
+InvalidateAll Evict every value, and discard the values of the loads in progress.
+*/
+func (self *CachingProxy[K, V]) InvalidateAll() {
+	self.mutex.Lock()
+	self.initialize()
+	var evictions []cacheEviction[K]
+	for self.order.Len() > 0 {
+		evictions = append(evictions, self.remove(self.order.Front(), EVICT_INVALIDATED))
+	}
+	for _, load := range self.loads {
+		load.invalidated = true
+	}
+	self.mutex.Unlock()
+
+	self.evict(evictions)
+}
+
+/*
+Len Count the cached values.
+
+- returns: the number of cached values, including the expired ones not evicted yet.
+*/
+func (self *CachingProxy[K, V]) Len() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return len(self.entries)
+}
+
+/*
+GetData Get the cached values, as required by IProxy
+
+- returns: a map[K]V of the values that did not expire.
+*/
+func (self *CachingProxy[K, V]) GetData() interface{} {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	var now = time.Now()
+	var values = map[K]V{}
+	for key, element := range self.entries {
+		if entry := element.Value.(*cacheEntry[K, V]); self.expired(entry, now) == false {
+			values[key] = entry.value
+		}
+	}
+	return values
+}
+
+/*
+SetData Cache values without loading them, as required by IProxy.
+
+Panics if the data is not a map[K]V.
+
+- parameter data: the values by key
+*/
+func (self *CachingProxy[K, V]) SetData(data interface{}) {
+	for key, value := range assertData[map[K]V](&self.BaseProxy, data) {
+		self.Put(key, value)
+	}
+}
+
+/*
+OnRegister Called by the Model when the Proxy is registered,
+observes the InvalidateNotifications.
+*/
+func (self *CachingProxy[K, V]) OnRegister() {
+	self.BaseProxy.OnRegister()
+	var v = view.GetInstance(self.Key, func() interfaces.IView { return &view.View{Key: self.Key} })
+	for _, name := range self.InvalidateNotifications {
+		v.RegisterObserver(name, &observer.Observer{Notify: self.handleInvalidation, Context: self})
+	}
+}
+
+/*
+OnRemove Called by the Model when the Proxy is removed,
+stops observing the InvalidateNotifications.
+*/
+func (self *CachingProxy[K, V]) OnRemove() {
+	self.BaseProxy.OnRemove()
+	var v = view.GetInstance(self.Key, func() interfaces.IView { return &view.View{Key: self.Key} })
+	for _, name := range self.InvalidateNotifications {
+		v.RemoveObserver(name, self)
+	}
+}
+
+/*
+handleInvalidation Invalidate the keys in the body of an INotification, or every value.
+*/
+func (self *CachingProxy[K, V]) handleInvalidation(notification interfaces.INotification) {
+	switch body := notification.Body().(type) {
+	case K:
+		self.Invalidate(body)
+	case []K:
+		for _, key := range body {
+			self.Invalidate(key)
+		}
+	default:
+		self.InvalidateAll()
+	}
+}
+
+/*
+load Call Loader for a miss and cache the value.
+
+If Loader panics, the load fails with an error wrapping ErrFetchPanicked.
+*/
+func (self *CachingProxy[K, V]) load(ctx context.Context, key K, load *cacheLoad[V]) {
+	var value V
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrFetchPanicked, r)
+		}
+		load.cancel()
+		var evictions []cacheEviction[K]
+
+		self.mutex.Lock()
+		if self.loads[key] == load {
+			delete(self.loads, key)
+		}
+		if err == nil {
+			load.value = value
+			if load.invalidated == false {
+				evictions = self.put(key, value)
+			}
+		}
+		load.err = err
+		self.mutex.Unlock()
+
+		self.evict(evictions)
+		close(load.done)
+	}()
+
+	value, err = self.Loader(ctx, key)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+}
+
+/*
+leave Stop waiting for a load, cancelling it if no other Get waits for it.
+
+A cancelled load is forgotten, so the next miss of the key loads it again.
+*/
+func (self *CachingProxy[K, V]) leave(key K, load *cacheLoad[V]) {
+	self.mutex.Lock()
+	load.waiters--
+	var abandoned = load.waiters == 0
+	if abandoned && self.loads[key] == load {
+		delete(self.loads, key)
+	}
+	self.mutex.Unlock()
+
+	if abandoned {
+		load.cancel()
+	}
+}
+
+/*
+initialize Create the maps of an empty cache. Must be called with the mutex held.
+*/
+func (self *CachingProxy[K, V]) initialize() {
+	if self.entries == nil {
+		self.entries = map[K]*list.Element{}
+		self.order = list.New()
+		self.loads = map[K]*cacheLoad[V]{}
+	}
+}
+
+/*
+put Cache a value, evicting the least recently used ones over MaxSize.
+Must be called with the mutex held.
+
+- returns: the evicted keys.
+*/
+func (self *CachingProxy[K, V]) put(key K, value V) []cacheEviction[K] {
+	var entry = &cacheEntry[K, V]{key: key, value: value}
+	if self.TTL > 0 {
+		entry.expires = time.Now().Add(self.TTL)
+	}
+
+	if element, ok := self.entries[key]; ok {
+		element.Value = entry
+		self.order.MoveToFront(element)
+	} else {
+		self.entries[key] = self.order.PushFront(entry)
+	}
+
+	var evictions []cacheEviction[K]
+	for self.MaxSize > 0 && self.order.Len() > self.MaxSize {
+		evictions = append(evictions, self.remove(self.order.Back(), EVICT_CAPACITY))
+	}
+	return evictions
+}
+
+/*
+remove Remove an element. Must be called with the mutex held.
+
+- returns: the eviction to report.
+*/
+func (self *CachingProxy[K, V]) remove(element *list.Element, reason EvictReason) cacheEviction[K] {
+	var key = element.Value.(*cacheEntry[K, V]).key
+	delete(self.entries, key)
+	self.order.Remove(element)
+	return cacheEviction[K]{key: key, reason: reason}
+}
+
+/*
+expired Check if an entry has expired.
+*/
+func (self *CachingProxy[K, V]) expired(entry *cacheEntry[K, V], now time.Time) bool {
+	return entry.expires.IsZero() == false && now.After(entry.expires)
+}
+
+/*
+hit Report a hit to OnHit, if set.
+*/
+func (self *CachingProxy[K, V]) hit(key K) {
+	if self.OnHit != nil {
+		self.OnHit(key)
+	}
+}
+
+/*
+miss Report a miss to OnMiss, if set.
+*/
+func (self *CachingProxy[K, V]) miss(key K) {
+	if self.OnMiss != nil {
+		self.OnMiss(key)
+	}
+}
+
+/*
+evict Report evictions to OnEvict, if set.
+*/
+func (self *CachingProxy[K, V]) evict(evictions []cacheEviction[K]) {
+	if self.OnEvict == nil {
+		return
+	}
+	for _, eviction := range evictions {
+		self.OnEvict(eviction.key, eviction.reason)
+	}
+}
@@ -0,0 +1,466 @@
+//
+//  CollectionProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var ErrItemExists = errors.New("item already exists")        // Add of a key that is in the collection
+var ErrItemNotFound = errors.New("item not found")           // Update of a key that is not in the collection
+var ErrUnknownIndex = errors.New("unknown collection index") // FindBy of an index that was not added
+
+/*
+CollectionProxy A generic IProxy implementation holding a keyed
+collection of items of type V.
+
+KeyOf extracts the key of an item, and must be set before the
+first item is added. Secondary indexes, added with AddIndex,
+extract a comparable value of each item to find the items by:
+
+	var users = &proxy.CollectionProxy[int, User]{BaseProxy: proxy.BaseProxy{Name: UserProxyName}, KeyOf: func(user User) int { return user.ID }}
+	users.AddIndex("email", func(user User) interface{} { return user.Email })
+	users.Add(User{ID: 1, Email: "ada@example.com"})
+	found, _ := users.FindBy("email", "ada@example.com")
+
+When AddedNotification, UpdatedNotification or RemovedNotification
+are set, every change of an item sends a notification with that
+name, the name of the CollectionProxy as its type, and an
+*ItemChange[K, V] body.
+
+Items are returned in the order they were added in, unless a
+CollectionQuery sorts them.
+*/
+type CollectionProxy[K comparable, V any] struct {
+	BaseProxy
+	KeyOf               func(item V) K                    // extracts the key of an item
+	AddedNotification   string                            // name of the notification sent when an item is added, "" for none
+	UpdatedNotification string                            // name of the notification sent when an item is updated, "" for none
+	RemovedNotification string                            // name of the notification sent when an item is removed, "" for none
+	items               map[K]*collectionItem[V]          // Mapping of keys to items
+	indexes             map[string]*collectionIndex[K, V] // Mapping of index names to indexes
+	sequence            int                               // counter of added items, for ordering them
+	mutex               sync.RWMutex                      // Mutex for items, indexes and sequence
+}
+
+/*
+collectionItem An item of a CollectionProxy and when it was added.
+*/
+type collectionItem[V any] struct {
+	value V
+	order int
+}
+
+/*
+collectionIndex A secondary index of a CollectionProxy.
+*/
+type collectionIndex[K comparable, V any] struct {
+	extract func(item V) interface{}
+	keys    map[interface{}]map[K]bool // Mapping of extracted values to the keys of the items
+}
+
+/*
+itemNotification A change of an item to be sent once the lock is released.
+*/
+type itemNotification[K comparable, V any] struct {
+	name   string
+	change *ItemChange[K, V]
+}
+
+/*
+Add Add an item to the collection.
+
+- parameter item: the item, whose key must not be in the collection
+
+- returns: an error wrapping ErrItemExists if the key is in the collection.
+*/
+func (self *CollectionProxy[K, V]) Add(item V) error {
+	var key = self.KeyOf(item)
+
+	return self.change(func() ([]*itemNotification[K, V], error) {
+		if _, ok := self.items[key]; ok {
+			return nil, fmt.Errorf("%w: %v in proxy %q", ErrItemExists, key, self.Name)
+		}
+		return []*itemNotification[K, V]{self.put(key, item)}, nil
+	})
+}
+
+/*
+Update Replace an item of the collection.
+
+- parameter item: the item, whose key must be in the collection
+
+- returns: an error wrapping ErrItemNotFound if the key is not in the collection.
+*/
+func (self *CollectionProxy[K, V]) Update(item V) error {
+	var key = self.KeyOf(item)
+
+	return self.change(func() ([]*itemNotification[K, V], error) {
+		if _, ok := self.items[key]; ok == false {
+			return nil, fmt.Errorf("%w: %v in proxy %q", ErrItemNotFound, key, self.Name)
+		}
+		return []*itemNotification[K, V]{self.put(key, item)}, nil
+	})
+}
+
+/*
+Put Add an item to the collection, or replace it if its key is in the collection.
+
+- parameter item: the item
+*/
+func (self *CollectionProxy[K, V]) Put(item V) {
+	var key = self.KeyOf(item)
+
+	_ = self.change(func() ([]*itemNotification[K, V], error) {
+		return []*itemNotification[K, V]{self.put(key, item)}, nil
+	})
+}
+
+/*
+Get Get an item by key.
+
+- parameter key: the key of the item
+
+- returns: the item, and whether it is in the collection.
+*/
+func (self *CollectionProxy[K, V]) Get(key K) (V, bool) {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	if entry, ok := self.items[key]; ok {
+		return entry.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+/*
+Has Check if an item is in the collection.
+
+- parameter key: the key of the item
+
+- returns: whether an item with the key is in the collection.
+*/
+func (self *CollectionProxy[K, V]) Has(key K) bool {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	_, ok := self.items[key]
+	return ok
+}
+
+/*
+Remove Remove an item by key.
+
+- parameter key: the key of the item
+
+- returns: the removed item, and whether it was in the collection.
+*/
+func (self *CollectionProxy[K, V]) Remove(key K) (V, bool) {
+	var removed V
+	var ok bool
+	_ = self.change(func() ([]*itemNotification[K, V], error) {
+		var entry *collectionItem[V]
+		if entry, ok = self.items[key]; ok == false {
+			return nil, nil
+		}
+		removed = entry.value
+		return []*itemNotification[K, V]{self.remove(key, entry)}, nil
+	})
+	return removed, ok
+}
+
+/*
+Replace Replace the items of the collection.
+
+Sends the removed notification for the items whose key is not
+among the new items, then the updated or added notification
+for each of the new items.
+
+- parameter items: the new items
+*/
+func (self *CollectionProxy[K, V]) Replace(items []V) {
+	var keys = make([]K, len(items))
+	var kept = make(map[K]bool, len(items))
+	for i, item := range items {
+		keys[i] = self.KeyOf(item)
+		kept[keys[i]] = true
+	}
+
+	_ = self.change(func() ([]*itemNotification[K, V], error) {
+		var notes []*itemNotification[K, V]
+		for _, key := range self.keys() {
+			if kept[key] == false {
+				notes = append(notes, self.remove(key, self.items[key]))
+			}
+		}
+		for i, item := range items {
+			notes = append(notes, self.put(keys[i], item))
+		}
+		return notes, nil
+	})
+}
+
+/*
+Len Count the items of the collection.
+
+- returns: the number of items.
+*/
+func (self *CollectionProxy[K, V]) Len() int {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	return len(self.items)
+}
+
+/*
+All Get the items of the collection.
+
+- returns: the items, in the order they were added in.
+*/
+func (self *CollectionProxy[K, V]) All() []V {
+	return self.Query(CollectionQuery[V]{}).Items
+}
+
+/*
+AddIndex Add a secondary index, or replace the one with the same name.
+
+The extracted values must be comparable, items extracting
+the same value are found together.
+
+- parameter name: the name of the index, for FindBy
+- parameter extract: returns the value of an item to find it by
+*/
+func (self *CollectionProxy[K, V]) AddIndex(name string, extract func(item V) interface{}) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	var index = &collectionIndex[K, V]{extract: extract, keys: map[interface{}]map[K]bool{}}
+	for key, entry := range self.items {
+		index.add(key, entry.value)
+	}
+	if self.indexes == nil {
+		self.indexes = map[string]*collectionIndex[K, V]{}
+	}
+	self.indexes[name] = index
+}
+
+/*
+FindBy Find the items by the value of a secondary index.
+
+- parameter index: the name of the index
+- parameter value: the value the items extract for the index
+
+- returns: the items, in the order they were added in, or an error wrapping ErrUnknownIndex.
+*/
+func (self *CollectionProxy[K, V]) FindBy(index string, value interface{}) ([]V, error) {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	var idx = self.indexes[index]
+	if idx == nil {
+		return nil, fmt.Errorf("%w: %q in proxy %q", ErrUnknownIndex, index, self.Name)
+	}
+
+	var entries []*collectionItem[V]
+	for key := range idx.keys[value] {
+		entries = append(entries, self.items[key])
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].order < entries[j].order })
+
+	var items = make([]V, len(entries))
+	for i, entry := range entries {
+		items[i] = entry.value
+	}
+	return items, nil
+}
+
+/*
+Query Filter, sort and paginate the items of the collection.
+
+The Filter and Less funcs of the query are called while the
+collection is locked for reading, and must not modify it.
+
+- parameter query: the CollectionQuery
+
+- returns: the CollectionPage of matching items.
+*/
+func (self *CollectionProxy[K, V]) Query(query CollectionQuery[V]) CollectionPage[V] {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	var items = []V{}
+	for _, key := range self.keys() {
+		var value = self.items[key].value
+		if query.Filter == nil || query.Filter(value) {
+			items = append(items, value)
+		}
+	}
+	if query.Less != nil {
+		sort.SliceStable(items, func(i, j int) bool { return query.Less(items[i], items[j]) })
+	}
+
+	var page = CollectionPage[V]{Total: len(items)}
+	var start = query.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	var end = len(items)
+	if query.Limit > 0 && query.Limit < end-start {
+		end = start + query.Limit
+	}
+	page.Items = items[start:end]
+	return page
+}
+
+/*
+GetData Get the items, as required by IProxy
+
+- returns: a []V of the items, in the order they were added in.
+*/
+func (self *CollectionProxy[K, V]) GetData() interface{} {
+	return self.All()
+}
+
+/*
+SetData Replace the items, as required by IProxy.
+
+Panics if the data is not a []V.
+
+- parameter data: the new items
+*/
+func (self *CollectionProxy[K, V]) SetData(data interface{}) {
+	self.Replace(assertData[[]V](&self.BaseProxy, data))
+}
+
+/*
+put Add or replace an item while holding the lock.
+
+- returns: the added or updated notification to send.
+*/
+func (self *CollectionProxy[K, V]) put(key K, item V) *itemNotification[K, V] {
+	if self.items == nil {
+		self.items = map[K]*collectionItem[V]{}
+	}
+
+	// extract the values first, so an extractor that panics leaves the collection unchanged
+	var values = make(map[*collectionIndex[K, V]]interface{}, len(self.indexes))
+	for _, index := range self.indexes {
+		values[index] = index.extract(item)
+	}
+
+	var change = &ItemChange[K, V]{ProxyName: self.Name, Key: key, New: item}
+	var name = self.AddedNotification
+	var entry, ok = self.items[key]
+	if ok {
+		change.Old = entry.value
+		name = self.UpdatedNotification
+		for _, index := range self.indexes {
+			index.remove(key, entry.value)
+		}
+		entry.value = item
+	} else {
+		self.sequence++
+		self.items[key] = &collectionItem[V]{value: item, order: self.sequence}
+	}
+
+	for index, value := range values {
+		index.insert(key, value)
+	}
+	return &itemNotification[K, V]{name: name, change: change}
+}
+
+/*
+remove Remove an item while holding the lock.
+
+- returns: the removed notification to send.
+*/
+func (self *CollectionProxy[K, V]) remove(key K, entry *collectionItem[V]) *itemNotification[K, V] {
+	delete(self.items, key)
+	for _, index := range self.indexes {
+		index.remove(key, entry.value)
+	}
+	return &itemNotification[K, V]{name: self.RemovedNotification, change: &ItemChange[K, V]{ProxyName: self.Name, Key: key, Old: entry.value}}
+}
+
+/*
+keys List the keys while holding the lock, in the order the items were added in.
+*/
+func (self *CollectionProxy[K, V]) keys() []K {
+	var keys = make([]K, 0, len(self.items))
+	for key := range self.items {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return self.items[keys[i]].order < self.items[keys[j]].order })
+	return keys
+}
+
+/*
+change Apply a change to the collection while holding the lock,
+then send its item notifications once the lock is released.
+
+The lock is released even if the change panics.
+
+- parameter apply: changes the collection, and returns the notifications to send or an error
+
+- returns: the error of apply.
+*/
+func (self *CollectionProxy[K, V]) change(apply func() ([]*itemNotification[K, V], error)) error {
+	var notes, err = func() ([]*itemNotification[K, V], error) {
+		self.mutex.Lock()
+		defer self.mutex.Unlock()
+		return apply()
+	}()
+
+	for _, note := range notes {
+		self.notifyItem(note)
+	}
+	return err
+}
+
+/*
+notifyItem Send an item notification, if configured and registered.
+*/
+func (self *CollectionProxy[K, V]) notifyItem(note *itemNotification[K, V]) {
+	self.notify(note.name, note.change)
+}
+
+/*
+add Index the key of an item.
+*/
+func (self *collectionIndex[K, V]) add(key K, item V) {
+	self.insert(key, self.extract(item))
+}
+
+/*
+insert Index a key under a value extracted from its item.
+*/
+func (self *collectionIndex[K, V]) insert(key K, value interface{}) {
+	if self.keys[value] == nil {
+		self.keys[value] = map[K]bool{}
+	}
+	self.keys[value][key] = true
+}
+
+/*
+remove Remove the key of an item from the index.
+*/
+func (self *collectionIndex[K, V]) remove(key K, item V) {
+	var value = self.extract(item)
+	delete(self.keys[value], key)
+	if len(self.keys[value]) == 0 {
+		delete(self.keys, value)
+	}
+}
@@ -0,0 +1,29 @@
+//
+//  CollectionQuery.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+CollectionQuery A query of the items of a CollectionProxy.
+
+The items are filtered, then sorted, then paginated.
+*/
+type CollectionQuery[V any] struct {
+	Filter func(item V) bool   // whether an item matches, nil for all items
+	Less   func(a V, b V) bool // whether a sorts before b, nil for the order the items were added in
+	Offset int                 // the number of matching items to skip, none if negative
+	Limit  int                 // the maximum number of items returned, no limit if 0 or negative
+}
+
+/*
+CollectionPage The result of a CollectionQuery.
+*/
+type CollectionPage[V any] struct {
+	Items []V // the matching items of the page
+	Total int // the number of matching items, before pagination
+}
@@ -0,0 +1,19 @@
+//
+//  DataChange.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+DataChange The body of the change notification of a TypedProxy or a VersionedProxy.
+*/
+type DataChange[T any] struct {
+	ProxyName string // the name of the TypedProxy
+	Old       T      // the data before it was set
+	New       T      // the data after it was set
+	Version   uint64 // the version after it was set, 0 for a TypedProxy
+}
@@ -0,0 +1,181 @@
+//
+//  Diff.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/*
+FieldChange A change to one field of the data of an ObservableProxy.
+*/
+type FieldChange struct {
+	Path string      // path of the field, e.g. "Address.City" or "Tags[home]", "" for the whole data
+	Old  interface{} // the value before the change, nil if it did not exist
+	New  interface{} // the value after the change, nil if it no longer exists
+}
+
+/*
+Diff The body of the change notifications of an ObservableProxy.
+*/
+type Diff struct {
+	ProxyName string        // the name of the ObservableProxy
+	Changes   []FieldChange // the changed fields, in field order, map entries sorted by key
+}
+
+/*
+Changed Check if a field, or any field nested in it, changed.
+
+- parameter path: the path of the field, e.g. "Address" matches a change of "Address.City"
+
+- returns: whether the field changed.
+*/
+func (self *Diff) Changed(path string) bool {
+	for _, change := range self.Changes {
+		if path == "" || change.Path == path || strings.HasPrefix(change.Path, path+".") || strings.HasPrefix(change.Path, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+diff Append the changes between two values of the same type.
+
+Exported struct fields and map entries are compared one by one,
+pointers are followed, and any other value is compared as a whole.
+*/
+func diff(old reflect.Value, new reflect.Value, path string, changes *[]FieldChange) {
+	switch old.Kind() {
+	case reflect.Struct:
+		for i := 0; i < old.NumField(); i++ {
+			if field := old.Type().Field(i); field.IsExported() {
+				diff(old.Field(i), new.Field(i), join(path, field.Name), changes)
+			}
+		}
+	case reflect.Map:
+		if old.IsNil() != new.IsNil() {
+			*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: new.Interface()})
+			return
+		}
+		for _, key := range mapKeys(old, new) {
+			var keyPath = fmt.Sprintf("%s[%v]", path, key.Interface())
+			var oldValue, newValue = old.MapIndex(key), new.MapIndex(key)
+			switch {
+			case oldValue.IsValid() == false:
+				*changes = append(*changes, FieldChange{Path: keyPath, New: newValue.Interface()})
+			case newValue.IsValid() == false:
+				*changes = append(*changes, FieldChange{Path: keyPath, Old: oldValue.Interface()})
+			default:
+				diff(oldValue, newValue, keyPath, changes)
+			}
+		}
+	case reflect.Ptr:
+		if old.IsNil() || new.IsNil() {
+			if old.IsNil() != new.IsNil() {
+				*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: new.Interface()})
+			}
+			return
+		}
+		diff(old.Elem(), new.Elem(), path, changes)
+	default:
+		if reflect.DeepEqual(old.Interface(), new.Interface()) == false {
+			*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: new.Interface()})
+		}
+	}
+}
+
+/*
+mapKeys Get the keys of two maps, sorted by their string representation.
+*/
+func mapKeys(old reflect.Value, new reflect.Value) []reflect.Value {
+	var keys = old.MapKeys()
+	for _, key := range new.MapKeys() {
+		if old.MapIndex(key).IsValid() == false {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+	return keys
+}
+
+/*
+join Join a field name to a path.
+*/
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+/*
+deepCopy Copy a value, along with the maps, slices and pointers
+reachable through its exported fields, so that changes made in
+place to the original can be diffed against the copy.
+
+The value must not contain cycles.
+*/
+func deepCopy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		var copied = reflect.New(value.Type().Elem())
+		copied.Elem().Set(deepCopy(value.Elem()))
+		return copied
+	case reflect.Struct:
+		var copied = reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				copied.Field(i).Set(deepCopy(value.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		var copied = reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		var copied = reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		var copied = reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		var copied = reflect.New(value.Type()).Elem()
+		copied.Set(deepCopy(value.Elem()))
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,35 @@
+//
+//  EvictReason.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+EvictReason Why a CachingProxy evicted a value.
+*/
+type EvictReason int
+
+const (
+	EVICT_EXPIRED     EvictReason = iota // the TTL of the value passed
+	EVICT_CAPACITY                       // the value was the least recently used one over MaxSize
+	EVICT_INVALIDATED                    // the value was invalidated
+)
+
+/*
+String Get the name of the EvictReason, for metrics.
+*/
+func (self EvictReason) String() string {
+	switch self {
+	case EVICT_EXPIRED:
+		return "expired"
+	case EVICT_CAPACITY:
+		return "capacity"
+	case EVICT_INVALIDATED:
+		return "invalidated"
+	}
+	return "unknown"
+}
@@ -0,0 +1,19 @@
+//
+//  ItemChange.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+ItemChange The body of the item notifications of a CollectionProxy.
+*/
+type ItemChange[K comparable, V any] struct {
+	ProxyName string // the name of the CollectionProxy
+	Key       K      // the key of the item
+	Old       V      // the item before the change, the zero value when added
+	New       V      // the item after the change, the zero value when removed
+}
@@ -0,0 +1,38 @@
+//
+//  LoadState.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+LoadState The state of the data of a RemoteProxy.
+*/
+type LoadState int
+
+const (
+	LOAD_IDLE    LoadState = iota // nothing was loaded yet
+	LOAD_LOADING                  // a load is in progress
+	LOAD_LOADED                   // the last load succeeded
+	LOAD_FAILED                   // the last load failed or was cancelled
+)
+
+/*
+String Get the name of the LoadState, for logging.
+*/
+func (self LoadState) String() string {
+	switch self {
+	case LOAD_IDLE:
+		return "idle"
+	case LOAD_LOADING:
+		return "loading"
+	case LOAD_LOADED:
+		return "loaded"
+	case LOAD_FAILED:
+		return "failed"
+	}
+	return "unknown"
+}
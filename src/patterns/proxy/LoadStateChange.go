@@ -0,0 +1,19 @@
+//
+//  LoadStateChange.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+LoadStateChange The body of the state notification of a RemoteProxy.
+*/
+type LoadStateChange struct {
+	ProxyName string    // the name of the RemoteProxy
+	Old       LoadState // the state before the change
+	New       LoadState // the state after the change
+	Err       error     // why the load failed, nil unless New is LOAD_FAILED
+}
@@ -0,0 +1,152 @@
+//
+//  ObservableProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"reflect"
+	"sync"
+)
+
+/*
+ObservableProxy A generic IProxy implementation that sends
+change notifications describing what changed in its data.
+
+The data is replaced as a whole with Set, or modified in
+place with Update:
+
+	user.Update(func(data *User) {
+	  data.Address.City = "Paris"
+	})
+
+After each change, if anything differs, a notification named
+ChangeNotification is sent with the name of the ObservableProxy
+as its type and a *Diff body listing every changed field.
+Exported struct fields and map entries are compared one by one,
+pointers are followed, and any other value is compared as a whole.
+
+With FieldNotifications, a notification is also sent for each
+changed field, named ChangeNotification + "." + the path of the
+field, unless the data is compared as a whole, so an IMediator may list precise fields as its interests:
+
+	return []string{USER_CHANGED + ".Address.City"}
+
+The data must not contain cycles.
+*/
+type ObservableProxy[T any] struct {
+	BaseProxy
+	Data               T          // the data object
+	ChangeNotification string     // name of the notification sent when the data changes, "" for none
+	FieldNotifications bool       // whether to also send a notification for each changed field
+	mutex              sync.Mutex // Mutex for Data
+}
+
+/*
+Get Get the data object
+*/
+func (self *ObservableProxy[T]) Get() T {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.Data
+}
+
+/*
+Set Replace the data object, sending the change notifications if anything differs.
+
+- parameter data: the new data
+*/
+func (self *ObservableProxy[T]) Set(data T) {
+	self.mutex.Lock()
+	var old = self.Data
+	self.Data = data
+	var changes = self.changes(reflect.ValueOf(&old).Elem(), reflect.ValueOf(&data).Elem())
+	self.mutex.Unlock()
+
+	self.notifyChanges(changes)
+}
+
+/*
+Update Modify the data object in place, sending the change notifications if anything differs.
+
+The update func runs while the ObservableProxy is locked,
+so it must not call the ObservableProxy.
+
+- parameter update: the func modifying the data
+*/
+func (self *ObservableProxy[T]) Update(update func(data *T)) {
+	self.mutex.Lock()
+	if self.observed() == false {
+		update(&self.Data)
+		self.mutex.Unlock()
+		return
+	}
+	var old = deepCopy(reflect.ValueOf(&self.Data).Elem())
+	update(&self.Data)
+	var changes = self.changes(old, reflect.ValueOf(&self.Data).Elem())
+	self.mutex.Unlock()
+
+	self.notifyChanges(changes)
+}
+
+/*
+GetData Get the data object, as required by IProxy
+*/
+func (self *ObservableProxy[T]) GetData() interface{} {
+	return self.Get()
+}
+
+/*
+SetData Replace the data object, as required by IProxy.
+
+Panics if the data is not a T.
+
+- parameter data: the new data
+*/
+func (self *ObservableProxy[T]) SetData(data interface{}) {
+	self.Set(assertData[T](&self.BaseProxy, data))
+}
+
+/*
+observed Check if change notifications are configured and can be sent.
+*/
+func (self *ObservableProxy[T]) observed() bool {
+	return self.notifying(self.ChangeNotification)
+}
+
+/*
+changes Diff two values of the data, if notifications are configured.
+*/
+func (self *ObservableProxy[T]) changes(old reflect.Value, new reflect.Value) []FieldChange {
+	if self.observed() == false {
+		return nil
+	}
+	var changes []FieldChange
+	diff(old, new, "", &changes)
+	return changes
+}
+
+/*
+notifyChanges Send the change notifications for a non-empty diff.
+*/
+func (self *ObservableProxy[T]) notifyChanges(changes []FieldChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	var body = &Diff{ProxyName: self.Name, Changes: changes}
+	self.notify(self.ChangeNotification, body)
+	if self.FieldNotifications {
+		for _, change := range changes {
+			if change.Path == "" {
+				continue
+			}
+			self.notify(self.ChangeNotification+"."+change.Path, body)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+//
+//  RemoteProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrFetchPanicked = errors.New("proxy fetch panicked") // the load failed because the Fetch or Loader func panicked
+
+/*
+RemoteProxy A generic IProxy implementation holding data of type T
+fetched from a remote service.
+
+Fetch loads the data, and may be any func, so a RemoteProxy is
+tested with local fakes. Its data goes from LOAD_IDLE to
+LOAD_LOADING, then LOAD_LOADED or LOAD_FAILED:
+
+	var users = &proxy.RemoteProxy[[]User]{BaseProxy: proxy.BaseProxy{Name: UserProxyName}, Fetch: client.ListUsers, StateNotification: USERS_STATE}
+	list, err := users.Load(ctx)
+
+Only one load is in progress at a time: Load and Refresh called
+meanwhile wait for it and share its result. The load runs on its
+own goroutine, and the context.Context of a call only stops that
+call waiting for it. The load is only cancelled by Cancel, or when
+the RemoteProxy is removed, and then fails with context.Canceled.
+Fetch should return promptly once its context.Context is done.
+
+When StateNotification is set, every change of the state sends a
+notification with that name, the name of the RemoteProxy as its
+type, and a *LoadStateChange body.
+*/
+type RemoteProxy[T any] struct {
+	BaseProxy
+	Fetch             func(ctx context.Context) (T, error) // loads the data
+	StateNotification string                               // name of the notification sent when the state changes, "" for none
+	data              T                                    // the data of the last successful load
+	err               error                                // the error of the last load, nil if it succeeded
+	state             LoadState                            // the state of the data
+	current           *remoteLoad[T]                       // the load in progress, nil if none
+	mutex             sync.Mutex                           // Mutex for data, err, state and current
+}
+
+/*
+remoteLoad A load of a RemoteProxy, shared by the calls waiting for it.
+*/
+type remoteLoad[T any] struct {
+	done      chan struct{}      // closed once the load is over
+	cancel    context.CancelFunc // cancels the context.Context of Fetch
+	data      T                  // the loaded data, set before done is closed
+	err       error              // the error of the load, set before done is closed
+	overtaken bool               // whether SetData was called meanwhile, so the result is not kept
+}
+
+/*
+Load Get the data, loading it unless it is loaded already.
+
+- parameter ctx: stops waiting for the load, which goes on for the other calls
+
+- returns: the data, or the error of the load or of the context.Context.
+*/
+func (self *RemoteProxy[T]) Load(ctx context.Context) (T, error) {
+	return self.load(ctx, false)
+}
+
+/*
+Refresh Load the data again, even if it is loaded already.
+
+Waits for the load in progress instead if there is one.
+
+- parameter ctx: stops waiting for the load, which goes on for the other calls
+
+- returns: the data, or the error of the load or of the context.Context.
+*/
+func (self *RemoteProxy[T]) Refresh(ctx context.Context) (T, error) {
+	return self.load(ctx, true)
+}
+
+/*
+Cancel Cancel the load in progress, if any.
+*/
+func (self *RemoteProxy[T]) Cancel() {
+	self.mutex.Lock()
+	var current = self.current
+	self.mutex.Unlock()
+
+	if current != nil {
+		current.cancel()
+	}
+}
+
+/*
+State Get the state of the data.
+*/
+func (self *RemoteProxy[T]) State() LoadState {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.state
+}
+
+/*
+Get Get the data of the last successful load, without loading it.
+*/
+func (self *RemoteProxy[T]) Get() T {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.data
+}
+
+/*
+Err Get the error of the last load.
+
+- returns: the error, nil if the last load succeeded or nothing was loaded.
+*/
+func (self *RemoteProxy[T]) Err() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.err
+}
+
+/*
+GetData Get the data of the last successful load, as required by IProxy
+*/
+func (self *RemoteProxy[T]) GetData() interface{} {
+	return self.Get()
+}
+
+/*
+SetData Set the data as if it was loaded, as required by IProxy.
+
+The load in progress, if any, is overtaken: the calls waiting
+for it still get its result, but the RemoteProxy keeps the data
+set here, and a later Refresh starts a new load.
+
+Panics if the data is not a T.
+
+- parameter data: the new data
+*/
+func (self *RemoteProxy[T]) SetData(data interface{}) {
+	var typed = assertData[T](&self.BaseProxy, data)
+
+	self.mutex.Lock()
+	var old = self.state
+	self.data = typed
+	self.err = nil
+	self.state = LOAD_LOADED
+	if self.current != nil {
+		self.current.overtaken = true
+		self.current = nil
+	}
+	self.mutex.Unlock()
+
+	self.notifyState(old, LOAD_LOADED, nil)
+}
+
+/*
+OnRemove Called by the Model when the Proxy is removed,
+cancels the load in progress.
+*/
+func (self *RemoteProxy[T]) OnRemove() {
+	self.BaseProxy.OnRemove()
+	self.Cancel()
+}
+
+/*
+load Start a load, or join the one in progress, and wait for it.
+*/
+func (self *RemoteProxy[T]) load(ctx context.Context, refresh bool) (T, error) {
+	self.mutex.Lock()
+	if refresh == false && self.current == nil && self.state == LOAD_LOADED {
+		var data = self.data
+		self.mutex.Unlock()
+		return data, nil
+	}
+
+	var current = self.current
+	if current == nil {
+		fetchCtx, cancel := context.WithCancel(context.Background())
+		current = &remoteLoad[T]{done: make(chan struct{}), cancel: cancel}
+		self.current = current
+		var old = self.state
+		self.state = LOAD_LOADING
+		self.mutex.Unlock()
+
+		self.notifyState(old, LOAD_LOADING, nil)
+		go self.fetch(fetchCtx, current)
+	} else {
+		self.mutex.Unlock()
+	}
+
+	select {
+	case <-current.done:
+		return current.data, current.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+/*
+fetch Run a load and record its result, unless it was overtaken by SetData.
+
+If Fetch panics, the load fails with an error wrapping ErrFetchPanicked.
+*/
+func (self *RemoteProxy[T]) fetch(ctx context.Context, load *remoteLoad[T]) {
+	var data T
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrFetchPanicked, r)
+		}
+		load.cancel()
+
+		self.mutex.Lock()
+		load.data = data
+		load.err = err
+		var overtaken = load.overtaken
+		var state = LOAD_LOADED
+		if err != nil {
+			state = LOAD_FAILED
+		}
+		if overtaken == false {
+			if err == nil {
+				self.data = data
+			}
+			self.err = err
+			self.state = state
+			self.current = nil
+		}
+		self.mutex.Unlock()
+
+		if overtaken == false {
+			self.notifyState(LOAD_LOADING, state, err)
+		}
+		close(load.done)
+	}()
+
+	data, err = self.Fetch(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+}
+
+/*
+notifyState Send the state notification, if configured and registered.
+*/
+func (self *RemoteProxy[T]) notifyState(old LoadState, new LoadState, err error) {
+	self.notify(self.StateNotification, &LoadStateChange{ProxyName: self.Name, Old: old, New: new, Err: err})
+}
@@ -0,0 +1,80 @@
+//
+//  TypedProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import "sync"
+
+/*
+TypedProxy A generic IProxy implementation holding data of type T.
+
+Get and Set access the data without type assertions,
+while GetData and SetData still satisfy IProxy.
+
+When ChangeNotification is set, every Set sends a notification
+with that name, the name of the TypedProxy as its type, and
+a *DataChange[T] body carrying the old and the new data:
+
+	var user = &proxy.TypedProxy[User]{BaseProxy: proxy.BaseProxy{Name: UserProxyName}, ChangeNotification: USER_CHANGED}
+	user.Set(User{Name: "Ada"})
+*/
+type TypedProxy[T any] struct {
+	BaseProxy
+	Data               T            // the data object
+	ChangeNotification string       // name of the notification sent when the data is set, "" for none
+	mutex              sync.RWMutex // Mutex for Data
+}
+
+/*
+Get Get the data object
+*/
+func (self *TypedProxy[T]) Get() T {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	return self.Data
+}
+
+/*
+Set Set the data object, sending the change notification if configured.
+
+- parameter data: the new data
+*/
+func (self *TypedProxy[T]) Set(data T) {
+	self.mutex.Lock()
+	var old = self.Data
+	self.Data = data
+	self.mutex.Unlock()
+
+	self.notifyChange(old, data)
+}
+
+/*
+GetData Get the data object, as required by IProxy
+*/
+func (self *TypedProxy[T]) GetData() interface{} {
+	return self.Get()
+}
+
+/*
+SetData Set the data object, as required by IProxy.
+
+Panics if the data is not a T.
+
+- parameter data: the new data
+*/
+func (self *TypedProxy[T]) SetData(data interface{}) {
+	self.Set(assertData[T](&self.BaseProxy, data))
+}
+
+/*
+notifyChange Send the change notification, if configured and registered.
+*/
+func (self *TypedProxy[T]) notifyChange(old T, new T) {
+	self.notify(self.ChangeNotification, &DataChange[T]{ProxyName: self.Name, Old: old, New: new})
+}
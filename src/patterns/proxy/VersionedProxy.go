@@ -0,0 +1,189 @@
+//
+//  VersionedProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrVersionConflict = errors.New("proxy version conflict") // the data changed since the version was read
+
+const DEFAULT_UPDATE_RETRIES = 10 // attempts of Update after the first conflict, if MaxRetries is 0
+
+/*
+VersionedProxy A generic IProxy implementation holding data of type T
+with optimistic concurrency.
+
+Every change of the data increments its version. Read returns the
+data with its version, and CompareAndSwap only replaces the data if
+the version did not move since, so concurrent Commands cannot
+silently overwrite each other's changes:
+
+	var stock = proxy.NewVersionedProxy(StockProxyName, 10)
+	count, version := stock.Read()
+	if _, err := stock.CompareAndSwap(version, count-1); errors.Is(err, proxy.ErrVersionConflict) {
+		// read again and retry, or give up
+	}
+
+Update does the read/modify/write cycle, retrying on conflicts.
+
+When ChangeNotification is set, every change sends a notification
+with that name, the name of the VersionedProxy as its type, and a
+*DataChange[T] body carrying the old and the new data and the new
+version.
+*/
+type VersionedProxy[T any] struct {
+	BaseProxy
+	data               T            // the data object
+	ChangeNotification string       // name of the notification sent when the data changes, "" for none
+	MaxRetries         int          // attempts of Update after the first conflict, DEFAULT_UPDATE_RETRIES if 0, none if negative
+	version            uint64       // incremented by every change of data
+	mutex              sync.RWMutex // Mutex for data and version
+}
+
+/*
+NewVersionedProxy Create a VersionedProxy holding initial data, at version 0.
+
+- parameter name: the proxy name
+
+- parameter data: the initial data
+
+- returns: the VersionedProxy.
+*/
+func NewVersionedProxy[T any](name string, data T) *VersionedProxy[T] {
+	return &VersionedProxy[T]{BaseProxy: BaseProxy{Name: name}, data: data}
+}
+
+/*
+Read Get the data object and its version.
+
+- returns: the data, and the version to pass to CompareAndSwap.
+*/
+func (self *VersionedProxy[T]) Read() (T, uint64) {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	return self.data, self.version
+}
+
+/*
+Version Get the version of the data object.
+
+- returns: the number of changes of the data.
+*/
+func (self *VersionedProxy[T]) Version() uint64 {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+
+	return self.version
+}
+
+/*
+Set Set the data object regardless of its version.
+
+- parameter data: the new data
+
+- returns: the new version.
+*/
+func (self *VersionedProxy[T]) Set(data T) uint64 {
+	self.mutex.Lock()
+	var old = self.data
+	self.data = data
+	self.version++
+	var version = self.version
+	self.mutex.Unlock()
+
+	self.notifyChange(old, data, version)
+	return version
+}
+
+/*
+CompareAndSwap Set the data object if its version is still the given one.
+
+- parameter version: the version the new data is based on, as returned by Read
+
+- parameter data: the new data
+
+- returns: the new version, or the current version and an error wrapping ErrVersionConflict if it moved.
+*/
+func (self *VersionedProxy[T]) CompareAndSwap(version uint64, data T) (uint64, error) {
+	self.mutex.Lock()
+	if self.version != version {
+		var current = self.version
+		self.mutex.Unlock()
+		return current, fmt.Errorf("%w: proxy %q expected version %d, at %d", ErrVersionConflict, self.Name, version, current)
+	}
+	var old = self.data
+	self.data = data
+	self.version++
+	version = self.version
+	self.mutex.Unlock()
+
+	self.notifyChange(old, data, version)
+	return version, nil
+}
+
+/*
+Update Read, modify and write the data object, retrying if it
+changed meanwhile.
+
+The modify func is called without holding any lock, possibly
+several times, and must return new data rather than modify the
+data it is given in place.
+
+- parameter modify: returns the new data based on the current data, or an error to abort
+
+- returns: the new version, or the error of modify, or an error wrapping ErrVersionConflict once the retries are exhausted.
+*/
+func (self *VersionedProxy[T]) Update(modify func(data T) (T, error)) (uint64, error) {
+	var retries = self.MaxRetries
+	if retries == 0 {
+		retries = DEFAULT_UPDATE_RETRIES
+	}
+
+	for attempt := 0; ; attempt++ {
+		data, version := self.Read()
+		data, err := modify(data)
+		if err != nil {
+			return version, err
+		}
+		version, err = self.CompareAndSwap(version, data)
+		if err == nil || attempt >= retries {
+			return version, err
+		}
+	}
+}
+
+/*
+GetData Get the data object, as required by IProxy
+*/
+func (self *VersionedProxy[T]) GetData() interface{} {
+	data, _ := self.Read()
+	return data
+}
+
+/*
+SetData Set the data object regardless of its version, as required by IProxy.
+
+Panics if the data is not a T.
+
+- parameter data: the new data
+*/
+func (self *VersionedProxy[T]) SetData(data interface{}) {
+	self.Set(assertData[T](&self.BaseProxy, data))
+}
+
+/*
+notifyChange Send the change notification, if configured and registered.
+*/
+func (self *VersionedProxy[T]) notifyChange(old T, new T, version uint64) {
+	self.notify(self.ChangeNotification, &DataChange[T]{ProxyName: self.Name, Old: old, New: new, Version: version})
+}
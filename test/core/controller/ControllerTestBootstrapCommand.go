@@ -0,0 +1,31 @@
+//
+//  ControllerTestBootstrapCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+ControllerTestBootstrapCommand A SimpleCommand subclass used by ControllerTest,
+that registers the ControllerTestCommand and removes its own mapping.
+*/
+type ControllerTestBootstrapCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Register ControllerTestCommand for the notification name
+in the body, and remove the mapping of this command.
+*/
+func (self *ControllerTestBootstrapCommand) Execute(notification interfaces.INotification) {
+	self.Facade.RegisterCommand(notification.Body().(string), func() interfaces.ICommand { return &ControllerTestCommand{} })
+	self.Facade.RemoveCommand(notification.Name())
+}
@@ -0,0 +1,40 @@
+//
+//  ControllerTestContextCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"time"
+)
+
+/*
+ControllerTestContextCommand A SimpleCommand subclass used by ControllerTest,
+that waits for its context or for the delay in the ControllerTestContextVO.
+*/
+type ControllerTestContextCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Wait for the delay, failing if the context is done first.
+
+- parameter note: the note carrying the ControllerTestContextVO
+*/
+func (self *ControllerTestContextCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestContextVO)
+
+	select {
+	case <-time.After(vo.Delay):
+		vo.Completed = true
+	case <-self.Context().Done():
+		vo.Cancelled <- self.Context().Err()
+		self.Fail(self.Context().Err())
+	}
+}
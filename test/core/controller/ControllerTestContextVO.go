@@ -0,0 +1,21 @@
+//
+//  ControllerTestContextVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import "time"
+
+/*
+ControllerTestContextVO A utility class used by ControllerTest.
+*/
+type ControllerTestContextVO struct {
+	Delay     time.Duration // how long the command takes
+	Completed bool          // whether the command completed
+	Cancelled chan error    // receives the context error if the command was cancelled
+	Release   chan struct{} // closed to release a stuck command
+}
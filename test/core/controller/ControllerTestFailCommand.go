@@ -0,0 +1,31 @@
+//
+//  ControllerTestFailCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+var ErrControllerTest = errors.New("controller test failure")
+
+/*
+ControllerTestFailCommand A SimpleCommand subclass used by ControllerTest, that always fails.
+*/
+type ControllerTestFailCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Fail with ErrControllerTest.
+*/
+func (self *ControllerTestFailCommand) Execute(notification interfaces.INotification) {
+	self.Fail(ErrControllerTest)
+}
@@ -0,0 +1,42 @@
+//
+//  ControllerTestIdempotentCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+ControllerTestIdempotentCommand A SimpleCommand subclass used by ControllerTest,
+identified by the ID of its ControllerTestIdempotentVO.
+*/
+type ControllerTestIdempotentCommand struct {
+	command.SimpleCommand
+}
+
+/*
+IdempotencyKey Get the ID of the ControllerTestIdempotentVO.
+*/
+func (self *ControllerTestIdempotentCommand) IdempotencyKey(notification interfaces.INotification) string {
+	return notification.Body().(*ControllerTestIdempotentVO).ID
+}
+
+/*
+Execute Count the execution, failing if the ControllerTestIdempotentVO says so.
+
+- parameter note: the note carrying the ControllerTestIdempotentVO
+*/
+func (self *ControllerTestIdempotentCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestIdempotentVO)
+	vo.Executions++
+	if vo.Fail {
+		self.Fail(ErrControllerTest)
+	}
+}
@@ -0,0 +1,18 @@
+//
+//  ControllerTestIdempotentVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+/*
+ControllerTestIdempotentVO A utility class used by ControllerTest.
+*/
+type ControllerTestIdempotentVO struct {
+	ID         string // the idempotency key
+	Fail       bool   // whether the command fails
+	Executions int    // how many times the command executed
+}
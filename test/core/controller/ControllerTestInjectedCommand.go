@@ -0,0 +1,37 @@
+//
+//  ControllerTestInjectedCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+)
+
+/*
+ControllerTestInjectedCommand A SimpleCommand subclass used by ControllerTest,
+whose dependencies are injected.
+*/
+type ControllerTestInjectedCommand struct {
+	command.SimpleCommand
+	Proxy    *proxy.Proxy         `inject:"proxy:ControllerTestProxy"`
+	Mediator interfaces.IMediator `inject:"mediator:ControllerTestMediator,optional"`
+	Model    interfaces.IModel    `inject:"model"`
+	History  interfaces.IHistory  `inject:"history"`
+}
+
+/*
+Execute Record the command in the ControllerTestInjectionVO.
+
+- parameter note: the note carrying the ControllerTestInjectionVO
+*/
+func (self *ControllerTestInjectedCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestInjectionVO)
+	vo.Executed = self
+}
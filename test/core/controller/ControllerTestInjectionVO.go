@@ -0,0 +1,16 @@
+//
+//  ControllerTestInjectionVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+/*
+ControllerTestInjectionVO A utility class used by ControllerTest.
+*/
+type ControllerTestInjectionVO struct {
+	Executed *ControllerTestInjectedCommand // the command as it was executed, nil if it was not
+}
@@ -0,0 +1,28 @@
+//
+//  ControllerTestPanicCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+ControllerTestPanicCommand A SimpleCommand subclass used by ControllerTest, that always panics.
+*/
+type ControllerTestPanicCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Panic with ErrControllerTest.
+*/
+func (self *ControllerTestPanicCommand) Execute(notification interfaces.INotification) {
+	panic(ErrControllerTest)
+}
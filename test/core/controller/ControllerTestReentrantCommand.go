@@ -0,0 +1,57 @@
+//
+//  ControllerTestReentrantCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"sync/atomic"
+)
+
+/*
+ControllerTestReentrantCommand A SimpleCommand subclass used by ControllerTest,
+that replaces, removes and registers mappings while it executes.
+*/
+type ControllerTestReentrantCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Count the execution, then churn the mappings named in the ControllerTestReentrantVO.
+*/
+func (self *ControllerTestReentrantCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestReentrantVO)
+	var n = atomic.AddInt64(&vo.Executions, 1)
+
+	var other = fmt.Sprint(vo.Prefix, n%int64(vo.Names))
+	var factory = func() interfaces.ICommand { return &ControllerTestReentrantCommand{} }
+
+	switch n % 4 {
+	case 0:
+		self.Facade.RegisterCommand(notification.Name(), factory) // replace own mapping
+	case 1:
+		self.Facade.RegisterCommand(other, factory)
+	case 2:
+		self.Facade.RemoveCommand(other)
+	case 3:
+		self.Facade.RemoveCommand(notification.Name()) // remove own mapping
+		self.Facade.RegisterCommand(notification.Name(), factory)
+	}
+	self.Facade.HasCommand(other)
+}
+
+/*
+ControllerTestReentrantVO A utility class used by ControllerTest.
+*/
+type ControllerTestReentrantVO struct {
+	Prefix     string // prefix of the notification names
+	Names      int    // number of notification names
+	Executions int64  // number of executions, updated atomically
+}
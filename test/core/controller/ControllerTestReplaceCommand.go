@@ -0,0 +1,34 @@
+//
+//  ControllerTestReplaceCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+ControllerTestReplaceCommand A SimpleCommand subclass used by ControllerTest,
+that replaces its own mapping with ControllerTestCommand2.
+*/
+type ControllerTestReplaceCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Replace the mapping of this command, then set the result to 100.
+
+- parameter note: the note carrying the ControllerTestVO
+*/
+func (self *ControllerTestReplaceCommand) Execute(notification interfaces.INotification) {
+	self.Facade.RegisterCommand(notification.Name(), func() interfaces.ICommand { return &ControllerTestCommand2{} })
+
+	var vo = notification.Body().(*ControllerTestVO)
+	vo.Result = 100
+}
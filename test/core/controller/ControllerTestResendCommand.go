@@ -0,0 +1,45 @@
+//
+//  ControllerTestResendCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"sync/atomic"
+)
+
+/*
+ControllerTestResendCommand A SimpleCommand subclass used by ControllerTest,
+that executes its own notification again while it executes.
+*/
+type ControllerTestResendCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Resend the notification while resends are left, then report the execution.
+*/
+func (self *ControllerTestResendCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestResendVO)
+	if atomic.AddInt32(&vo.Resends, -1) >= 0 {
+		var c = controller.GetInstance(self.Key, func() interfaces.IController { return &controller.Controller{Key: self.Key} })
+		c.ExecuteCommand(observer.NewNotification(notification.Name(), vo, ""))
+	}
+	vo.Executed <- true
+}
+
+/*
+ControllerTestResendVO A utility class used by ControllerTest.
+*/
+type ControllerTestResendVO struct {
+	Resends  int32     // number of resends left, updated atomically
+	Executed chan bool // receives a value per execution
+}
@@ -0,0 +1,48 @@
+//
+//  ControllerTestRetryCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+var ErrControllerTestTransient = errors.New("controller test transient failure")
+
+/*
+ControllerTestRetryCommand A SimpleCommand subclass used by ControllerTest,
+failing with the error of the ControllerTestRetryVO until its
+number of attempts reaches SucceedAt.
+*/
+type ControllerTestRetryCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Count the attempt and fail unless it is the one to succeed.
+
+- parameter note: the note carrying the ControllerTestRetryVO
+*/
+func (self *ControllerTestRetryCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestRetryVO)
+	vo.Attempts++
+	if vo.Attempts != vo.SucceedAt {
+		self.Fail(vo.Err)
+	}
+}
+
+/*
+ControllerTestRetryVO A utility class used by ControllerTest.
+*/
+type ControllerTestRetryVO struct {
+	SucceedAt int   // the attempt that succeeds, 0 for none
+	Err       error // the error failed attempts report
+	Attempts  int   // the number of attempts made
+}
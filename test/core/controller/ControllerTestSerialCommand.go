@@ -0,0 +1,78 @@
+//
+//  ControllerTestSerialCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"sync"
+	"time"
+)
+
+/*
+ControllerTestSerialCommand A SimpleCommand subclass used by ControllerTest,
+recording how many executions run at once for each key.
+*/
+type ControllerTestSerialCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Record the execution in the ControllerTestSerialState.
+
+- parameter note: the note carrying the ControllerTestSerialVO
+*/
+func (self *ControllerTestSerialCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestSerialVO)
+	var state = vo.State
+	defer state.WaitGroup.Done()
+
+	state.Mutex.Lock()
+	state.Active[vo.Key]++
+	if state.Active[vo.Key] > state.MaxActive[vo.Key] {
+		state.MaxActive[vo.Key] = state.Active[vo.Key]
+	}
+	state.Order[vo.Key] = append(state.Order[vo.Key], vo.Index)
+	state.Mutex.Unlock()
+
+	if vo.Wait != nil {
+		<-vo.Wait
+	}
+	if vo.Signal != nil {
+		close(vo.Signal)
+	}
+	time.Sleep(time.Millisecond + vo.Sleep)
+
+	state.Mutex.Lock()
+	state.Active[vo.Key]--
+	state.Mutex.Unlock()
+}
+
+/*
+ControllerTestSerialVO A utility class used by ControllerTest.
+*/
+type ControllerTestSerialVO struct {
+	Key    string                     // the serial key of the execution
+	Index  int                        // the dispatch order within the key
+	Wait   chan struct{}              // closed to let the execution finish, nil to not wait
+	Signal chan struct{}              // closed by the execution, nil for none
+	Sleep  time.Duration              // extra time the execution takes
+	State  *ControllerTestSerialState // state shared by every execution
+}
+
+/*
+ControllerTestSerialState A utility class used by ControllerTest.
+*/
+type ControllerTestSerialState struct {
+	Mutex     sync.Mutex
+	WaitGroup sync.WaitGroup
+	Active    map[string]int   // executions running for each key
+	MaxActive map[string]int   // most executions that ran at once for each key
+	Order     map[string][]int // indexes in execution order for each key
+}
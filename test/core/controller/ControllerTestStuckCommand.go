@@ -0,0 +1,32 @@
+//
+//  ControllerTestStuckCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package controller
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+ControllerTestStuckCommand A SimpleCommand subclass used by ControllerTest,
+that ignores its context and blocks until released.
+*/
+type ControllerTestStuckCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Block until the ControllerTestContextVO is released.
+
+- parameter note: the note carrying the ControllerTestContextVO
+*/
+func (self *ControllerTestStuckCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*ControllerTestContextVO)
+	<-vo.Release
+}
@@ -9,11 +9,19 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 /*
@@ -172,3 +180,777 @@ func TestReregisterAndExecuteCommand(t *testing.T) {
 		t.Error("Expecting vo.result == 48")
 	}
 }
+
+/*
+Tests that a Command exceeding the timeout of its mapping
+has its context cancelled and is reported to the error handler.
+*/
+func TestCommandTimeout(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey6", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey6"} })
+	c.RegisterCommand("ControllerTimeoutTest", func() interfaces.ICommand { return &ControllerTestContextCommand{} }, controller.WithTimeout(10*time.Millisecond))
+
+	var commandError *controller.CommandError
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError = err })
+
+	var vo = &ControllerTestContextVO{Delay: time.Second, Cancelled: make(chan error, 1)}
+	c.ExecuteCommand(observer.NewNotification("ControllerTimeoutTest", vo, ""))
+
+	if vo.Completed == true {
+		t.Error("Expecting vo.Completed == false")
+	}
+	if commandError == nil {
+		t.Fatal("Expecting a CommandError")
+	}
+	if errors.Is(commandError, context.DeadlineExceeded) == false {
+		t.Error("Expecting the CommandError to wrap context.DeadlineExceeded")
+	}
+	if commandError.Notification.Name() != "ControllerTimeoutTest" {
+		t.Error("Expecting commandError.Notification.Name() == 'ControllerTimeoutTest'")
+	}
+	if _, ok := commandError.Command.(*ControllerTestContextCommand); ok == false {
+		t.Error("Expecting the CommandError to carry the timed out Command, got", commandError.Command)
+	}
+	if err := <-vo.Cancelled; errors.Is(err, context.DeadlineExceeded) == false {
+		t.Error("Expecting the command context to be cancelled with context.DeadlineExceeded")
+	}
+}
+
+/*
+Tests that a Command completing within the timeout of its mapping succeeds.
+*/
+func TestCommandWithinTimeout(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey7", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey7"} })
+	c.RegisterCommand("ControllerTimeoutTest", func() interfaces.ICommand { return &ControllerTestContextCommand{} }, controller.WithTimeout(time.Second))
+
+	var commandError *controller.CommandError
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError = err })
+
+	var vo = &ControllerTestContextVO{Delay: time.Millisecond, Cancelled: make(chan error, 1)}
+	c.ExecuteCommand(observer.NewNotification("ControllerTimeoutTest", vo, ""))
+
+	if vo.Completed == false {
+		t.Error("Expecting vo.Completed == true")
+	}
+	if commandError != nil {
+		t.Error("Expecting no CommandError, got", commandError)
+	}
+}
+
+/*
+Tests that a stuck Command does not block registering
+or removing Commands while it executes.
+*/
+func TestStuckCommandDoesNotBlockRegistration(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey8", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey8"} })
+	c.RegisterCommand("ControllerStuckTest", func() interfaces.ICommand { return &ControllerTestStuckCommand{} })
+
+	var vo = &ControllerTestContextVO{Release: make(chan struct{})}
+	var executed = make(chan struct{})
+	go func() {
+		c.ExecuteCommand(observer.NewNotification("ControllerStuckTest", vo, ""))
+		close(executed)
+	}()
+
+	var registered = make(chan struct{})
+	go func() {
+		c.RegisterCommand("ControllerOtherTest", func() interfaces.ICommand { return &ControllerTestCommand{} })
+		c.RemoveCommand("ControllerStuckTest")
+		close(registered)
+	}()
+
+	select {
+	case <-registered:
+	case <-time.After(time.Second):
+		t.Error("Expecting RegisterCommand and RemoveCommand not to block")
+	}
+
+	close(vo.Release)
+	<-executed
+}
+
+/*
+Tests that a failing Command is sent as a COMMAND_ERROR
+notification when no error handler is set.
+*/
+func TestCommandErrorNotification(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey9", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey9"} })
+	var v = view.GetInstance("ControllerTestKey9", func() interfaces.IView { return &view.View{Key: "ControllerTestKey9"} })
+	c.RegisterCommand("ControllerFailTest", func() interfaces.ICommand { return &ControllerTestFailCommand{} })
+
+	var commandError *controller.CommandError
+	v.RegisterObserver(controller.COMMAND_ERROR, &observer.Observer{Notify: func(notification interfaces.INotification) {
+		commandError = notification.Body().(*controller.CommandError)
+	}, Context: t})
+
+	v.NotifyObservers(observer.NewNotification("ControllerFailTest", nil, ""))
+
+	if commandError == nil {
+		t.Fatal("Expecting a COMMAND_ERROR notification")
+	}
+	if errors.Is(commandError, ErrControllerTest) == false {
+		t.Error("Expecting the CommandError to wrap ErrControllerTest")
+	}
+	if _, ok := commandError.Command.(*ControllerTestFailCommand); ok == false {
+		t.Error("Expecting commandError.Command to be the ControllerTestFailCommand")
+	}
+}
+
+/*
+Tests that a failing Command is retried with a new
+instance until it succeeds, and that each retry is reported.
+*/
+func TestCommandRetry(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey10", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey10"} })
+	c.RegisterCommand("ControllerRetryTest", func() interfaces.ICommand { return &ControllerTestRetryCommand{} },
+		controller.WithRetry(&controller.RetryPolicy{MaxAttempts: 5, InitialDelay: time.Millisecond, Multiplier: 2, Jitter: 0.5}))
+
+	var commandError *controller.CommandError
+	var attempts []int
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError = err })
+	c.(*controller.Controller).SetRetryHandler(func(attempt *controller.RetryAttempt) {
+		if attempt.Err != ErrControllerTestTransient || attempt.Delay > 4*time.Millisecond {
+			t.Error("Unexpected RetryAttempt", attempt)
+		}
+		attempts = append(attempts, attempt.Attempt)
+	})
+
+	var vo = &ControllerTestRetryVO{SucceedAt: 3, Err: ErrControllerTestTransient}
+	c.ExecuteCommand(observer.NewNotification("ControllerRetryTest", vo, ""))
+
+	if vo.Attempts != 3 {
+		t.Error("Expecting vo.Attempts == 3, got", vo.Attempts)
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Error("Expecting retries after attempts 1 and 2, got", attempts)
+	}
+	if commandError != nil {
+		t.Error("Expecting no CommandError, got", commandError)
+	}
+}
+
+/*
+Tests that a Command is reported once its attempts are exhausted,
+and that errors rejected by the Retryable predicate are not retried.
+*/
+func TestCommandRetryExhausted(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey11", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey11"} })
+	var policy = &controller.RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool { return err == ErrControllerTestTransient }}
+	c.RegisterCommand("ControllerRetryTest", func() interfaces.ICommand { return &ControllerTestRetryCommand{} }, controller.WithRetry(policy))
+
+	var commandError *controller.CommandError
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError = err })
+
+	var vo = &ControllerTestRetryVO{Err: ErrControllerTestTransient}
+	c.ExecuteCommand(observer.NewNotification("ControllerRetryTest", vo, ""))
+
+	if vo.Attempts != 3 {
+		t.Error("Expecting vo.Attempts == 3, got", vo.Attempts)
+	}
+	if commandError == nil || errors.Is(commandError, ErrControllerTestTransient) == false {
+		t.Error("Expecting a CommandError wrapping ErrControllerTestTransient")
+	}
+
+	commandError = nil
+	vo = &ControllerTestRetryVO{Err: ErrControllerTest}
+	c.ExecuteCommand(observer.NewNotification("ControllerRetryTest", vo, ""))
+
+	if vo.Attempts != 1 {
+		t.Error("Expecting vo.Attempts == 1, got", vo.Attempts)
+	}
+	if commandError == nil || errors.Is(commandError, ErrControllerTest) == false {
+		t.Error("Expecting a CommandError wrapping ErrControllerTest")
+	}
+}
+
+/*
+Tests that the timeout of a mapping ends the delay between retries.
+*/
+func TestCommandRetryTimeout(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey12", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey12"} })
+	c.RegisterCommand("ControllerRetryTest", func() interfaces.ICommand { return &ControllerTestRetryCommand{} },
+		controller.WithRetry(&controller.RetryPolicy{MaxAttempts: 5, InitialDelay: time.Hour}), controller.WithTimeout(10*time.Millisecond))
+
+	var commandError = make(chan *controller.CommandError, 1)
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError <- err })
+
+	var start = time.Now()
+	c.ExecuteCommand(observer.NewNotification("ControllerRetryTest", &ControllerTestRetryVO{Err: ErrControllerTestTransient}, ""))
+
+	if time.Since(start) > time.Second {
+		t.Error("Expecting the timeout to end the delay between retries")
+	}
+	if err := <-commandError; errors.Is(err, context.DeadlineExceeded) == false {
+		t.Error("Expecting a CommandError wrapping context.DeadlineExceeded")
+	}
+}
+
+/*
+Tests that Commands with the same serial key run one at a time,
+in order, while Commands with different keys run in parallel.
+*/
+func TestSerialKey(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey13", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey13"} })
+	var key = func(notification interfaces.INotification) string {
+		return notification.Body().(*ControllerTestSerialVO).Key
+	}
+	c.RegisterCommand("ControllerSerialTest", func() interfaces.ICommand { return &ControllerTestSerialCommand{} }, controller.WithSerialKey(key))
+	c.RegisterCommand("ControllerSerialTest2", func() interfaces.ICommand { return &ControllerTestSerialCommand{} }, controller.WithSerialKey(key))
+
+	var state = &ControllerTestSerialState{Active: map[string]int{}, MaxActive: map[string]int{}, Order: map[string][]int{}}
+
+	// the first execution for "a" only finishes once the first one for "b" ran
+	var signal = make(chan struct{})
+	state.WaitGroup.Add(2)
+	c.ExecuteCommand(observer.NewNotification("ControllerSerialTest", &ControllerTestSerialVO{Key: "a", Index: 0, Wait: signal, State: state}, ""))
+	c.ExecuteCommand(observer.NewNotification("ControllerSerialTest", &ControllerTestSerialVO{Key: "b", Index: 0, Signal: signal, State: state}, ""))
+
+	var keys = []string{"a", "b", "c"}
+	for i := 1; i <= 10; i++ {
+		for _, k := range keys {
+			state.WaitGroup.Add(1)
+			var name = "ControllerSerialTest"
+			if i%2 == 0 {
+				name = "ControllerSerialTest2"
+			}
+			c.ExecuteCommand(observer.NewNotification(name, &ControllerTestSerialVO{Key: k, Index: i, State: state}, ""))
+		}
+	}
+
+	var done = make(chan struct{})
+	go func() {
+		state.WaitGroup.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expecting different serial keys to run in parallel")
+	}
+
+	for _, k := range keys {
+		if state.MaxActive[k] != 1 {
+			t.Error("Expecting one execution at a time for key", k, "got", state.MaxActive[k])
+		}
+		for i, index := range state.Order[k] {
+			if (k == "c" && index != i+1) || (k != "c" && index != i) {
+				t.Error("Expecting executions in dispatch order for key", k, "got", state.Order[k])
+				break
+			}
+		}
+	}
+}
+
+/*
+Tests that a lane waits for a timed out ICommand to return
+before starting the next one, and still reports the timeout.
+*/
+func TestSerialKeyTimeout(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey29", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey29"} })
+	c.RegisterCommand("ControllerSerialTimeoutTest", func() interfaces.ICommand { return &ControllerTestSerialCommand{} },
+		controller.WithSerialKey(func(notification interfaces.INotification) string { return "lane" }),
+		controller.WithTimeout(5*time.Millisecond))
+
+	var mutex sync.Mutex
+	var timeouts = 0
+	c.(*controller.Controller).SetErrorHandler(func(commandError *controller.CommandError) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if errors.Is(commandError.Err, context.DeadlineExceeded) {
+			timeouts++
+		}
+	})
+
+	var state = &ControllerTestSerialState{Active: map[string]int{}, MaxActive: map[string]int{}, Order: map[string][]int{}}
+	for i := 0; i < 3; i++ {
+		state.WaitGroup.Add(1)
+		c.ExecuteCommand(observer.NewNotification("ControllerSerialTimeoutTest", &ControllerTestSerialVO{Key: "lane", Index: i, Sleep: 30 * time.Millisecond, State: state}, ""))
+	}
+	// the timeout of the last slow execution is reported before this one starts
+	state.WaitGroup.Add(1)
+	c.ExecuteCommand(observer.NewNotification("ControllerSerialTimeoutTest", &ControllerTestSerialVO{Key: "lane", Index: 3, State: state}, ""))
+	state.WaitGroup.Wait()
+
+	if state.MaxActive["lane"] != 1 {
+		t.Error("Expecting timed out executions never to overlap, got", state.MaxActive["lane"])
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if timeouts != 3 {
+		t.Error("Expecting 3 timeouts reported, got", timeouts)
+	}
+}
+
+/*
+Tests that the executions of a lane queued by OVERFLOW_QUEUE
+still run in dispatch order.
+*/
+func TestSerialKeyQueuedOverflow(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey28", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey28"} })
+	c.RegisterCommand("ControllerSerialQueueTest", func() interfaces.ICommand { return &ControllerTestSerialCommand{} },
+		controller.WithSerialKey(func(notification interfaces.INotification) string { return "lane" }),
+		controller.WithRateLimit(50, 1),
+		controller.WithOverflow(interfaces.OVERFLOW_QUEUE))
+
+	var state = &ControllerTestSerialState{Active: map[string]int{}, MaxActive: map[string]int{}, Order: map[string][]int{}}
+	for i := 0; i < 8; i++ {
+		state.WaitGroup.Add(1)
+		c.ExecuteCommand(observer.NewNotification("ControllerSerialQueueTest", &ControllerTestSerialVO{Key: "lane", Index: i, State: state}, ""))
+	}
+	state.WaitGroup.Wait()
+
+	for i, index := range state.Order["lane"] {
+		if index != i {
+			t.Fatal("Expecting executions in dispatch order, got", state.Order["lane"])
+		}
+	}
+	if state.MaxActive["lane"] != 1 {
+		t.Error("Expecting one execution at a time, got", state.MaxActive["lane"])
+	}
+}
+
+/*
+Tests the bootstrap pattern: a Command that registers
+other Commands and removes its own mapping while it executes.
+*/
+func TestCommandRegistersCommandsDuringExecution(t *testing.T) {
+	var f = facade.GetInstance("ControllerTestKey14", func() interfaces.IFacade { return &facade.Facade{Key: "ControllerTestKey14"} })
+	f.RegisterCommand("ControllerBootstrapTest", func() interfaces.ICommand { return &ControllerTestBootstrapCommand{} })
+
+	var done = make(chan struct{})
+	go func() {
+		f.SendNotification("ControllerBootstrapTest", "ControllerTest", "")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expecting a Command to register and remove Commands without deadlocking")
+	}
+
+	if f.HasCommand("ControllerBootstrapTest") == true {
+		t.Error("Expecting the bootstrap Command to have removed its own mapping")
+	}
+
+	var vo = &ControllerTestVO{Input: 4}
+	f.SendNotification("ControllerTest", vo, "")
+	if vo.Result != 8 {
+		t.Error("Expecting vo.Result == 8")
+	}
+}
+
+/*
+Tests that a Command replacing its own mapping finishes its
+execution, and the replacement handles the next notification.
+*/
+func TestCommandReplacesOwnMapping(t *testing.T) {
+	var f = facade.GetInstance("ControllerTestKey15", func() interfaces.IFacade { return &facade.Facade{Key: "ControllerTestKey15"} })
+	f.RegisterCommand("ControllerReplaceTest", func() interfaces.ICommand { return &ControllerTestReplaceCommand{} })
+
+	var vo = &ControllerTestVO{Input: 3}
+	f.SendNotification("ControllerReplaceTest", vo, "")
+	if vo.Result != 100 {
+		t.Error("Expecting vo.Result == 100")
+	}
+
+	f.SendNotification("ControllerReplaceTest", vo, "")
+	if vo.Result != 106 {
+		t.Error("Expecting vo.Result == 106")
+	}
+}
+
+/*
+Stress tests concurrent dispatch while Commands, and other
+goroutines, register, replace and remove mappings.
+
+Run with -race.
+*/
+func TestReentrantRegistrationStress(t *testing.T) {
+	var f = facade.GetInstance("ControllerTestKey16", func() interfaces.IFacade { return &facade.Facade{Key: "ControllerTestKey16"} })
+	var vo = &ControllerTestReentrantVO{Prefix: "ControllerStressTest", Names: 8}
+	for i := 0; i < vo.Names; i++ {
+		f.RegisterCommand(fmt.Sprint(vo.Prefix, i), func() interfaces.ICommand { return &ControllerTestReentrantCommand{} })
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				var name = fmt.Sprint(vo.Prefix, (g+i)%vo.Names)
+				switch i % 10 {
+				case 0:
+					f.RemoveCommand(name)
+				case 1:
+					f.RegisterCommand(name, func() interfaces.ICommand { return &ControllerTestReentrantCommand{} })
+				default:
+					f.SendNotification(name, vo, "")
+				}
+			}
+		}(g)
+	}
+
+	var done = make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expecting concurrent registration during execution not to deadlock")
+	}
+
+	if vo.Executions == 0 {
+		t.Error("Expecting some executions")
+	}
+}
+
+/*
+Tests that executions over the rate limit of a mapping
+are rejected and reported.
+*/
+func TestRateLimitRejects(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey17", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey17"} })
+	c.RegisterCommand("ControllerRateTest", func() interfaces.ICommand { return &ControllerTestCommand2{} }, controller.WithRateLimit(0.001, 2))
+
+	var rejected []error
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { rejected = append(rejected, err.Err) })
+
+	var vo = &ControllerTestVO{Input: 1}
+	for i := 0; i < 3; i++ {
+		c.ExecuteCommand(observer.NewNotification("ControllerRateTest", vo, ""))
+	}
+
+	if vo.Result != 4 {
+		t.Error("Expecting the burst of 2 executions, vo.Result == 4, got", vo.Result)
+	}
+	if len(rejected) != 1 || errors.Is(rejected[0], controller.ErrRateLimited) == false {
+		t.Error("Expecting one ErrRateLimited, got", rejected)
+	}
+}
+
+/*
+Tests that executions over the rate limit of a mapping
+wait their turn with OVERFLOW_QUEUE.
+*/
+func TestRateLimitQueues(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey18", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey18"} })
+	c.RegisterCommand("ControllerRateTest", func() interfaces.ICommand { return &ControllerTestResendCommand{} },
+		controller.WithRateLimit(50, 1), controller.WithOverflow(interfaces.OVERFLOW_QUEUE))
+
+	var vo = &ControllerTestResendVO{Executed: make(chan bool, 3)}
+	var start = time.Now()
+	for i := 0; i < 3; i++ {
+		c.ExecuteCommand(observer.NewNotification("ControllerRateTest", vo, ""))
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-vo.Executed:
+		case <-time.After(time.Second):
+			t.Fatal("Expecting every execution, got", i)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Error("Expecting the executions to be spaced by the rate limit, took", elapsed)
+	}
+}
+
+/*
+Tests that a Command holding the only in-flight slot of its
+mapping may send its own notification with OVERFLOW_QUEUE.
+*/
+func TestMaxInFlightQueuesReentrantExecution(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey27", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey27"} })
+	c.RegisterCommand("ControllerResendTest", func() interfaces.ICommand { return &ControllerTestResendCommand{} },
+		controller.WithMaxInFlight(1), controller.WithOverflow(interfaces.OVERFLOW_QUEUE))
+
+	var vo = &ControllerTestResendVO{Resends: 1, Executed: make(chan bool, 2)}
+	c.ExecuteCommand(observer.NewNotification("ControllerResendTest", vo, ""))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-vo.Executed:
+		case <-time.After(time.Second):
+			t.Fatal("Expecting the resent notification to execute once the slot is released, got", i)
+		}
+	}
+}
+
+/*
+Tests that an execution abandoned after its timeout keeps
+its in-flight slot until its Command returns.
+*/
+func TestMaxInFlightRejects(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey19", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey19"} })
+	c.RegisterCommand("ControllerStuckTest", func() interfaces.ICommand { return &ControllerTestStuckCommand{} },
+		controller.WithTimeout(10*time.Millisecond), controller.WithMaxInFlight(1))
+
+	var rejected = make(chan error, 4)
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { rejected <- err.Err })
+
+	var vo = &ControllerTestContextVO{Release: make(chan struct{})}
+	c.ExecuteCommand(observer.NewNotification("ControllerStuckTest", vo, ""))
+	if err := <-rejected; errors.Is(err, context.DeadlineExceeded) == false {
+		t.Error("Expecting the first execution to time out, got", err)
+	}
+
+	c.ExecuteCommand(observer.NewNotification("ControllerStuckTest", vo, ""))
+	if err := <-rejected; errors.Is(err, controller.ErrTooManyInFlight) == false {
+		t.Error("Expecting ErrTooManyInFlight while the first Command runs, got", err)
+	}
+
+	close(vo.Release)
+	var deadline = time.Now().Add(time.Second)
+	for admitted := false; admitted == false; {
+		c.ExecuteCommand(observer.NewNotification("ControllerStuckTest", vo, ""))
+		select {
+		case err := <-rejected:
+			if errors.Is(err, controller.ErrTooManyInFlight) == false {
+				t.Fatal("Expecting only ErrTooManyInFlight, got", err)
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("Expecting the slot to be released once the Command returns")
+			}
+			time.Sleep(time.Millisecond)
+		default:
+			admitted = true
+		}
+	}
+}
+
+/*
+Tests that executions over the in-flight cap of a mapping
+are silently skipped with OVERFLOW_DROP.
+*/
+func TestMaxInFlightDrops(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey20", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey20"} })
+	c.RegisterCommand("ControllerDropTest", func() interfaces.ICommand { return &ControllerTestContextCommand{} },
+		controller.WithMaxInFlight(1), controller.WithOverflow(interfaces.OVERFLOW_DROP))
+
+	var reported = false
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { reported = true })
+
+	var first = &ControllerTestContextVO{Delay: 100 * time.Millisecond}
+	var done = make(chan struct{})
+	go func() {
+		c.ExecuteCommand(observer.NewNotification("ControllerDropTest", first, ""))
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	var second = &ControllerTestContextVO{}
+	c.ExecuteCommand(observer.NewNotification("ControllerDropTest", second, ""))
+	<-done
+
+	if first.Completed == false {
+		t.Error("Expecting the first execution to complete")
+	}
+	if second.Completed == true {
+		t.Error("Expecting the second execution to be dropped")
+	}
+	if reported == true {
+		t.Error("Expecting a dropped execution not to be reported")
+	}
+}
+
+/*
+Tests that the tagged fields of a Command are injected before it executes.
+*/
+func TestInjector(t *testing.T) {
+	var f = facade.GetInstance("ControllerTestKey21", func() interfaces.IFacade { return &facade.Facade{Key: "ControllerTestKey21"} })
+	var c = controller.GetInstance("ControllerTestKey21", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey21"} })
+	c.(*controller.Controller).SetInjector(&controller.Injector{Key: "ControllerTestKey21"})
+	c.RegisterCommand("ControllerInjectionTest", func() interfaces.ICommand { return &ControllerTestInjectedCommand{} })
+
+	var p = &proxy.Proxy{Name: "ControllerTestProxy"}
+	f.RegisterProxy(p)
+
+	var vo = &ControllerTestInjectionVO{}
+	f.SendNotification("ControllerInjectionTest", vo, "")
+
+	if vo.Executed == nil {
+		t.Fatal("Expecting the command to be executed")
+	}
+	if vo.Executed.Proxy != p {
+		t.Error("Expecting the proxy to be injected")
+	}
+	if vo.Executed.Mediator != nil {
+		t.Error("Expecting the missing optional mediator not to be injected")
+	}
+	if vo.Executed.Model == nil || vo.Executed.History == nil {
+		t.Error("Expecting the core services to be injected")
+	}
+}
+
+/*
+Tests that a Command with a missing dependency is not
+executed and the error names the dependency.
+*/
+func TestInjectorMissingDependency(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey22", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey22"} })
+	c.(*controller.Controller).SetInjector(&controller.Injector{Key: "ControllerTestKey22"})
+	c.RegisterCommand("ControllerInjectionTest", func() interfaces.ICommand { return &ControllerTestInjectedCommand{} })
+
+	var commandError *controller.CommandError
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError = err })
+
+	var vo = &ControllerTestInjectionVO{}
+	c.ExecuteCommand(observer.NewNotification("ControllerInjectionTest", vo, ""))
+
+	if vo.Executed != nil {
+		t.Error("Expecting the command not to be executed")
+	}
+	if commandError == nil || errors.Is(commandError, controller.ErrMissingDependency) == false {
+		t.Fatal("Expecting ErrMissingDependency, got", commandError)
+	}
+	if strings.Contains(commandError.Error(), "proxy:ControllerTestProxy") == false {
+		t.Error("Expecting the error to name the dependency, got", commandError.Error())
+	}
+}
+
+/*
+Tests that the before and after hooks run around each
+execution, with the elapsed time and the result.
+*/
+func TestHooks(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey23", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey23"} })
+	c.RegisterCommand("ControllerHookTest", func() interfaces.ICommand { return &ControllerTestContextCommand{} })
+	c.RegisterCommand("ControllerHookFailTest", func() interfaces.ICommand { return &ControllerTestFailCommand{} })
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) {})
+
+	var log []string
+	var executions []*controller.CommandExecution
+	c.(*controller.Controller).AddBeforeHook(func(execution *controller.CommandExecution) {
+		log = append(log, "before "+execution.Notification.Name())
+	})
+	c.(*controller.Controller).AddAfterHook(func(execution *controller.CommandExecution) {
+		log = append(log, "after "+execution.Notification.Name())
+		executions = append(executions, execution)
+	})
+
+	var vo = &ControllerTestContextVO{Delay: 10 * time.Millisecond}
+	c.ExecuteCommand(observer.NewNotification("ControllerHookTest", vo, ""))
+	c.ExecuteCommand(observer.NewNotification("ControllerHookFailTest", nil, ""))
+
+	var expected = []string{"before ControllerHookTest", "after ControllerHookTest", "before ControllerHookFailTest", "after ControllerHookFailTest"}
+	if fmt.Sprint(log) != fmt.Sprint(expected) {
+		t.Fatal("Expecting", expected, "got", log)
+	}
+	if _, ok := executions[0].Command.(*ControllerTestContextCommand); ok == false {
+		t.Error("Expecting the hook to receive the command instance")
+	}
+	if executions[0].Elapsed < vo.Delay || executions[0].Err != nil || executions[0].Attempt != 1 {
+		t.Error("Expecting a successful execution of at least", vo.Delay, "got", executions[0])
+	}
+	if executions[1].Err != ErrControllerTest {
+		t.Error("Expecting the after hook to receive the error, got", executions[1].Err)
+	}
+}
+
+/*
+Tests that the after hooks receive the panic of a
+Command, after which the panic resumes.
+*/
+func TestHooksPanic(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey24", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey24"} })
+	c.RegisterCommand("ControllerHookPanicTest", func() interfaces.ICommand { return &ControllerTestPanicCommand{} })
+
+	var after *controller.CommandExecution
+	c.(*controller.Controller).AddAfterHook(func(execution *controller.CommandExecution) { after = execution })
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		c.ExecuteCommand(observer.NewNotification("ControllerHookPanicTest", nil, ""))
+	}()
+
+	if after == nil || after.Panic != ErrControllerTest {
+		t.Error("Expecting the after hook to receive the panic")
+	}
+	if recovered != ErrControllerTest {
+		t.Error("Expecting the panic to resume after the hooks, got", recovered)
+	}
+}
+
+/*
+Tests that a duplicate execution of an IIdempotentCommand
+is skipped and reported, and that a failed execution
+releases its key.
+*/
+func TestIdempotentCommand(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey25", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey25"} })
+	c.RegisterCommand("ControllerIdempotentTest", func() interfaces.ICommand { return &ControllerTestIdempotentCommand{} })
+
+	var reported []error
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { reported = append(reported, err.Err) })
+
+	var vo = &ControllerTestIdempotentVO{ID: "order-1"}
+	c.ExecuteCommand(observer.NewNotification("ControllerIdempotentTest", vo, ""))
+	c.ExecuteCommand(observer.NewNotification("ControllerIdempotentTest", vo, ""))
+
+	if vo.Executions != 1 {
+		t.Error("Expecting a single execution, got", vo.Executions)
+	}
+	if len(reported) != 1 || errors.Is(reported[0], controller.ErrDuplicate) == false {
+		t.Error("Expecting the duplicate to be reported, got", reported)
+	}
+
+	var failing = &ControllerTestIdempotentVO{ID: "order-2", Fail: true}
+	c.ExecuteCommand(observer.NewNotification("ControllerIdempotentTest", failing, ""))
+	failing.Fail = false
+	c.ExecuteCommand(observer.NewNotification("ControllerIdempotentTest", failing, ""))
+
+	if failing.Executions != 2 {
+		t.Error("Expecting the failed execution to release its key, got", failing.Executions, "executions")
+	}
+}
+
+/*
+Tests the expiry and eviction of the MemoryDedupeStore.
+*/
+func TestMemoryDedupeStore(t *testing.T) {
+	var store = &controller.MemoryDedupeStore{Capacity: 2, TTL: 20 * time.Millisecond}
+
+	if store.Claim("a") == false || store.Claim("a") == true {
+		t.Error("Expecting 'a' to be claimed once")
+	}
+
+	store.Claim("b")
+	store.Claim("c")
+	if store.Claim("a") == false {
+		t.Error("Expecting 'a' to be evicted as least recently claimed")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if store.Claim("c") == false {
+		t.Error("Expecting 'c' to have expired")
+	}
+
+	store.Release("c")
+	if store.Claim("c") == false {
+		t.Error("Expecting 'c' to be released")
+	}
+}
+
+/*
+Tests listing and counting the registered Commands.
+*/
+func TestListCommands(t *testing.T) {
+	var c = controller.GetInstance("ControllerTestKey26", func() interfaces.IController { return &controller.Controller{Key: "ControllerTestKey26"} })
+	c.RegisterCommand("ControllerListTestB", func() interfaces.ICommand { return &ControllerTestCommand{} })
+	c.RegisterCommand("ControllerListTestA", func() interfaces.ICommand { return &ControllerTestCommand{} })
+
+	if fmt.Sprint(c.ListCommands()) != "[ControllerListTestA ControllerListTestB]" {
+		t.Error("Expecting the sorted Notification names, got", c.ListCommands())
+	}
+	if c.CommandCount() != 2 {
+		t.Error("Expecting c.CommandCount() == 2")
+	}
+
+	c.RemoveCommand("ControllerListTestA")
+	if fmt.Sprint(c.ListCommands()) != "[ControllerListTestB]" || c.CommandCount() != 1 {
+		t.Error("Expecting only ControllerListTestB, got", c.ListCommands())
+	}
+}
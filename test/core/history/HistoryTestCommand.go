@@ -0,0 +1,41 @@
+//
+//  HistoryTestCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+HistoryTestCommand An undoable SimpleCommand subclass used by HistoryTest.
+*/
+type HistoryTestCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Add the input to the result
+
+- parameter note: the note carrying the HistoryTestVO
+*/
+func (self *HistoryTestCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*HistoryTestVO)
+	vo.Result = vo.Result + vo.Input
+}
+
+/*
+Undo Subtract the input from the result
+
+- parameter note: the note carrying the HistoryTestVO
+*/
+func (self *HistoryTestCommand) Undo(notification interfaces.INotification) {
+	var vo = notification.Body().(*HistoryTestVO)
+	vo.Result = vo.Result - vo.Input
+}
@@ -0,0 +1,79 @@
+//
+//  HistoryTestMacroCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+HistoryTestMacroCommand A MacroCommand subclass used by HistoryTest,
+executing HistoryTestCommand twice.
+*/
+type HistoryTestMacroCommand struct {
+	command.MacroCommand
+}
+
+func (self *HistoryTestMacroCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &HistoryTestCommand{} })
+	self.AddSubCommand(func() interfaces.ICommand { return &HistoryTestCommand{} })
+	self.MacroCommand.Execute(notification)
+}
+
+/*
+HistoryTestPanickingMacroCommand A MacroCommand subclass used by HistoryTest,
+executing HistoryTestCommand, then a SubCommand that panics.
+*/
+type HistoryTestPanickingMacroCommand struct {
+	command.MacroCommand
+}
+
+func (self *HistoryTestPanickingMacroCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &HistoryTestCommand{} })
+	self.AddSubCommand(func() interfaces.ICommand { return &HistoryTestPanicCommand{} })
+	self.MacroCommand.Execute(notification)
+}
+
+/*
+HistoryTestPanicCommand A SimpleCommand subclass used by HistoryTest, that always panics.
+*/
+type HistoryTestPanicCommand struct {
+	command.SimpleCommand
+}
+
+func (self *HistoryTestPanicCommand) Execute(notification interfaces.INotification) {
+	panic("history test panic")
+}
+
+/*
+HistoryTestFailingMacroCommand A MacroCommand subclass used by HistoryTest,
+executing HistoryTestCommand, then a SubCommand that fails.
+*/
+type HistoryTestFailingMacroCommand struct {
+	command.MacroCommand
+}
+
+func (self *HistoryTestFailingMacroCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &HistoryTestCommand{} })
+	self.AddSubCommand(func() interfaces.ICommand { return &HistoryTestFailCommand{} })
+	self.MacroCommand.Execute(notification)
+}
+
+/*
+HistoryTestFailCommand A SimpleCommand subclass used by HistoryTest, that always fails.
+*/
+type HistoryTestFailCommand struct {
+	command.SimpleCommand
+}
+
+func (self *HistoryTestFailCommand) Execute(notification interfaces.INotification) {
+	self.Fail(errors.New("history test failure"))
+}
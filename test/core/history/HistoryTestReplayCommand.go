@@ -0,0 +1,49 @@
+//
+//  HistoryTestReplayCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+)
+
+/*
+HistoryTestReplayCommand An undoable SimpleCommand subclass used by HistoryTest,
+whose Undo executes a HistoryTestCommand, then queues another one in a serial lane.
+*/
+type HistoryTestReplayCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Add the input to the result
+
+- parameter note: the note carrying the HistoryTestVO
+*/
+func (self *HistoryTestReplayCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*HistoryTestVO)
+	vo.Result = vo.Result + vo.Input
+}
+
+/*
+Undo Subtract the input from the result, then execute
+HistoryTestCommand and queue another one in a serial lane.
+
+- parameter note: the note carrying the HistoryTestVO
+*/
+func (self *HistoryTestReplayCommand) Undo(notification interfaces.INotification) {
+	var vo = notification.Body().(*HistoryTestVO)
+	vo.Result = vo.Result - vo.Input
+
+	var c = controller.GetInstance(self.Key, func() interfaces.IController { return &controller.Controller{Key: self.Key} })
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", &HistoryTestVO{Input: 1}, ""))
+	c.ExecuteCommand(observer.NewNotification("HistoryQueuedTest", &HistoryTestVO{Input: 1}, ""))
+}
@@ -0,0 +1,31 @@
+//
+//  HistoryTestSignalCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+HistoryTestSignalCommand A SimpleCommand subclass used by HistoryTest,
+closing the channel in the body of its note, to tell when a lane got to it.
+*/
+type HistoryTestSignalCommand struct {
+	command.SimpleCommand
+}
+
+/*
+Execute Close the channel in the body of the note.
+
+- parameter note: the note carrying a chan bool
+*/
+func (self *HistoryTestSignalCommand) Execute(notification interfaces.INotification) {
+	close(notification.Body().(chan bool))
+}
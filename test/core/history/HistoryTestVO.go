@@ -0,0 +1,17 @@
+//
+//  HistoryTestVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+/*
+HistoryTestVO A utility class used by HistoryTest.
+*/
+type HistoryTestVO struct {
+	Input  int
+	Result int
+}
@@ -0,0 +1,318 @@
+//
+//  History_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package history
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/history"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"testing"
+)
+
+/*
+Test the PureMVC History class.
+*/
+
+/*
+Tests the History Multiton Factory Method
+*/
+func TestGetInstance(t *testing.T) {
+	var h = history.GetInstance("HistoryTestKey1", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey1"} })
+
+	if h == nil {
+		t.Error("Expecting instance not nil")
+	}
+}
+
+/*
+Tests that an IUndoableCommand executed by the Controller
+is recorded, and undone and redone via notifications.
+*/
+func TestUndoAndRedoViaNotifications(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey2", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey2"} })
+	var v = view.GetInstance("HistoryTestKey2", func() interfaces.IView { return &view.View{Key: "HistoryTestKey2"} })
+	var h = history.GetInstance("HistoryTestKey2", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey2"} })
+	c.RegisterCommand("HistoryTest", func() interfaces.ICommand { return &HistoryTestCommand{} })
+
+	var vo = &HistoryTestVO{Input: 5}
+	v.NotifyObservers(observer.NewNotification("HistoryTest", vo, ""))
+	v.NotifyObservers(observer.NewNotification("HistoryTest", vo, ""))
+
+	if vo.Result != 10 {
+		t.Error("Expecting vo.Result == 10")
+	}
+	if h.CanUndo() == false {
+		t.Error("Expecting history.CanUndo() == true")
+	}
+
+	v.NotifyObservers(observer.NewNotification(history.UNDO, nil, ""))
+	if vo.Result != 5 {
+		t.Error("Expecting vo.Result == 5 after undo")
+	}
+	if h.CanRedo() == false {
+		t.Error("Expecting history.CanRedo() == true")
+	}
+
+	v.NotifyObservers(observer.NewNotification(history.REDO, nil, ""))
+	if vo.Result != 10 {
+		t.Error("Expecting vo.Result == 10 after redo")
+	}
+	if h.CanRedo() == true {
+		t.Error("Expecting history.CanRedo() == false")
+	}
+}
+
+/*
+Tests that a new step discards the steps that could be redone.
+*/
+func TestRecordDiscardsRedo(t *testing.T) {
+	var h = history.GetInstance("HistoryTestKey3", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey3"} })
+
+	var vo = &HistoryTestVO{Input: 1}
+	var note = observer.NewNotification("HistoryTest", vo, "")
+	var cmd = &HistoryTestCommand{}
+	cmd.Execute(note)
+	h.Record(cmd, note)
+	h.Undo()
+
+	if h.CanRedo() == false {
+		t.Error("Expecting history.CanRedo() == true")
+	}
+
+	cmd.Execute(note)
+	h.Record(cmd, note)
+
+	if h.CanRedo() == true {
+		t.Error("Expecting history.CanRedo() == false")
+	}
+}
+
+/*
+Tests that a group of commands is undone and redone as one step.
+*/
+func TestGroup(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey4", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey4"} })
+	var h = history.GetInstance("HistoryTestKey4", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey4"} })
+	c.RegisterCommand("HistoryTest", func() interfaces.ICommand { return &HistoryTestCommand{} })
+
+	var vo = &HistoryTestVO{Input: 3}
+	h.BeginGroup()
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, ""))
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, ""))
+	h.EndGroup()
+
+	if vo.Result != 6 {
+		t.Error("Expecting vo.Result == 6")
+	}
+
+	h.Undo()
+	if vo.Result != 0 {
+		t.Error("Expecting vo.Result == 0 after undoing the group")
+	}
+	if h.CanUndo() == true {
+		t.Error("Expecting history.CanUndo() == false")
+	}
+
+	h.Redo()
+	if vo.Result != 6 {
+		t.Error("Expecting vo.Result == 6 after redoing the group")
+	}
+}
+
+/*
+Tests that the undoable SubCommands of a MacroCommand form one step.
+*/
+func TestMacroCommandGroup(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey5", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey5"} })
+	var h = history.GetInstance("HistoryTestKey5", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey5"} })
+	c.RegisterCommand("HistoryMacroTest", func() interfaces.ICommand { return &HistoryTestMacroCommand{} })
+
+	var vo = &HistoryTestVO{Input: 4}
+	c.ExecuteCommand(observer.NewNotification("HistoryMacroTest", vo, ""))
+
+	if vo.Result != 8 {
+		t.Error("Expecting vo.Result == 8")
+	}
+
+	h.Undo()
+	if vo.Result != 0 {
+		t.Error("Expecting vo.Result == 0 after undo")
+	}
+	if h.CanUndo() == true {
+		t.Error("Expecting history.CanUndo() == false")
+	}
+}
+
+/*
+Tests that the limit discards the oldest steps.
+*/
+func TestLimit(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey6", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey6"} })
+	var h = history.GetInstance("HistoryTestKey6", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey6"} })
+	c.RegisterCommand("HistoryTest", func() interfaces.ICommand { return &HistoryTestCommand{} })
+	h.SetLimit(2)
+
+	var vo = &HistoryTestVO{Input: 1}
+	for i := 0; i < 5; i++ {
+		c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, ""))
+	}
+
+	var undone = 0
+	for h.Undo() {
+		undone++
+	}
+
+	if undone != 2 {
+		t.Error("Expecting 2 steps undone, got", undone)
+	}
+	if vo.Result != 3 {
+		t.Error("Expecting vo.Result == 3")
+	}
+}
+
+/*
+Tests that HISTORY_CHANGED is sent only when availability changes.
+*/
+func TestHistoryChangedNotification(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey7", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey7"} })
+	var v = view.GetInstance("HistoryTestKey7", func() interfaces.IView { return &view.View{Key: "HistoryTestKey7"} })
+	var h = history.GetInstance("HistoryTestKey7", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey7"} })
+	c.RegisterCommand("HistoryTest", func() interfaces.ICommand { return &HistoryTestCommand{} })
+
+	var states []history.State
+	v.RegisterObserver(history.HISTORY_CHANGED, &observer.Observer{Notify: func(notification interfaces.INotification) {
+		states = append(states, *notification.Body().(*history.State))
+	}, Context: t})
+
+	var vo = &HistoryTestVO{Input: 1}
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, "")) // undo becomes available
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, "")) // no change
+	h.Undo()                                                          // redo becomes available
+	h.Undo()                                                          // undo becomes unavailable
+	h.Clear()                                                         // redo becomes unavailable
+
+	var expected = []history.State{{CanUndo: true}, {CanUndo: true, CanRedo: true}, {CanRedo: true}, {}}
+	if len(states) != len(expected) {
+		t.Fatal("Expecting", len(expected), "HISTORY_CHANGED notifications, got", len(states))
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Error("Expecting state", i, "==", expected[i], "got", states[i])
+		}
+	}
+}
+
+/*
+Tests that the executions queued in a serial lane while a
+group is open are recorded in the group, even if they run
+after EndGroup.
+*/
+func TestGroupCollectsQueuedExecutions(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey8", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey8"} })
+	var v = view.GetInstance("HistoryTestKey8", func() interfaces.IView { return &view.View{Key: "HistoryTestKey8"} })
+	var h = history.GetInstance("HistoryTestKey8", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey8"} })
+	c.RegisterCommand("HistoryTest", func() interfaces.ICommand { return &HistoryTestCommand{} },
+		controller.WithSerialKey(func(notification interfaces.INotification) string { return "lane" }))
+
+	var changed = make(chan bool, 1)
+	v.RegisterObserver(history.HISTORY_CHANGED, &observer.Observer{Notify: func(notification interfaces.INotification) {
+		select {
+		case changed <- true:
+		default:
+		}
+	}, Context: t})
+
+	var vo = &HistoryTestVO{Input: 3}
+	h.BeginGroup()
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, ""))
+	c.ExecuteCommand(observer.NewNotification("HistoryTest", vo, ""))
+	h.EndGroup()
+	<-changed
+
+	if vo.Result != 6 {
+		t.Error("Expecting vo.Result == 6, got", vo.Result)
+	}
+	h.Undo()
+	if vo.Result != 0 || h.CanUndo() == true {
+		t.Error("Expecting both executions undone as one step, got", vo.Result)
+	}
+}
+
+/*
+Tests that the commands sent while a step is undone are not
+recorded, even if they run later in a serial lane.
+*/
+func TestReplayIgnoresQueuedExecutions(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey9", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey9"} })
+	var h = history.GetInstance("HistoryTestKey9", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey9"} })
+	var lane = controller.WithSerialKey(func(notification interfaces.INotification) string { return "lane" })
+	c.RegisterCommand("HistoryTest", func() interfaces.ICommand { return &HistoryTestCommand{} })
+	c.RegisterCommand("HistoryQueuedTest", func() interfaces.ICommand { return &HistoryTestCommand{} }, lane)
+	c.RegisterCommand("HistoryLaneDone", func() interfaces.ICommand { return &HistoryTestSignalCommand{} }, lane)
+	c.RegisterCommand("HistoryReplayTest", func() interfaces.ICommand { return &HistoryTestReplayCommand{} })
+
+	c.ExecuteCommand(observer.NewNotification("HistoryReplayTest", &HistoryTestVO{Input: 2}, ""))
+	h.Undo()
+	var done = make(chan bool)
+	c.ExecuteCommand(observer.NewNotification("HistoryLaneDone", done, ""))
+	<-done
+
+	if h.CanUndo() == true {
+		t.Error("Expecting no command sent during the undo to be recorded")
+	}
+	if h.CanRedo() == false {
+		t.Error("Expecting the undone step to be redoable")
+	}
+}
+
+/*
+Tests that a MacroCommand that panics records no partial step.
+*/
+func TestMacroCommandPanicRecordsNothing(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey10", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey10"} })
+	var h = history.GetInstance("HistoryTestKey10", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey10"} })
+	c.RegisterCommand("HistoryMacroTest", func() interfaces.ICommand { return &HistoryTestPanickingMacroCommand{} })
+
+	var vo = &HistoryTestVO{Input: 4}
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		c.ExecuteCommand(observer.NewNotification("HistoryMacroTest", vo, ""))
+	}()
+
+	if recovered == nil || vo.Result != 4 {
+		t.Error("Expecting the first SubCommand to execute before the panic, got", vo.Result)
+	}
+	if h.CanUndo() == true {
+		t.Error("Expecting no step recorded for the MacroCommand that panicked")
+	}
+}
+
+/*
+Tests that a failing MacroCommand records no partial step,
+even when its mapping retries it.
+*/
+func TestMacroCommandFailureRecordsNothing(t *testing.T) {
+	var c = controller.GetInstance("HistoryTestKey11", func() interfaces.IController { return &controller.Controller{Key: "HistoryTestKey11"} })
+	var h = history.GetInstance("HistoryTestKey11", func() interfaces.IHistory { return &history.History{Key: "HistoryTestKey11"} })
+	c.RegisterCommand("HistoryMacroTest", func() interfaces.ICommand { return &HistoryTestFailingMacroCommand{} },
+		controller.WithRetry(&controller.RetryPolicy{MaxAttempts: 2}))
+
+	var vo = &HistoryTestVO{Input: 4}
+	c.ExecuteCommand(observer.NewNotification("HistoryMacroTest", vo, ""))
+
+	if vo.Result != 8 {
+		t.Error("Expecting the first SubCommand executed by both attempts, got", vo.Result)
+	}
+	if h.CanUndo() == true {
+		t.Error("Expecting no step recorded for the failed MacroCommand")
+	}
+}
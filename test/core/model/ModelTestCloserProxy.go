@@ -0,0 +1,24 @@
+//
+//  ModelTestCloserProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package model
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+/*
+ModelTestCloserProxy A Proxy subclass used by ModelTest, that implements io.Closer.
+*/
+type ModelTestCloserProxy struct {
+	proxy.Proxy
+	Closed bool
+}
+
+func (self *ModelTestCloserProxy) Close() error {
+	self.Closed = true
+	return nil
+}
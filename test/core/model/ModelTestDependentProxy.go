@@ -0,0 +1,33 @@
+//
+//  ModelTestDependentProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package model
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+/*
+ModelTestDependentProxy A Proxy subclass used by ModelTest,
+that depends on other Proxies and logs its lifecycle.
+*/
+type ModelTestDependentProxy struct {
+	proxy.Proxy
+	Dependencies []string
+	Log          *[]string
+}
+
+func (self *ModelTestDependentProxy) ListProxyDependencies() []string {
+	return self.Dependencies
+}
+
+func (self *ModelTestDependentProxy) OnRegister() {
+	*self.Log = append(*self.Log, "register "+self.Name)
+}
+
+func (self *ModelTestDependentProxy) OnRemove() {
+	*self.Log = append(*self.Log, "remove "+self.Name)
+}
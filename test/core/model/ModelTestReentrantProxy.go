@@ -0,0 +1,44 @@
+//
+//  ModelTestReentrantProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package model
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+const REGISTER_OTHER = "registerOther"
+const REMOVE_SELF = "removeSelf"
+
+/*
+ModelTestReentrantProxy A Proxy class used by ModelTest,
+that uses the Model from its OnRegister.
+*/
+type ModelTestReentrantProxy struct {
+	proxy.Proxy
+	Action string    // REGISTER_OTHER or REMOVE_SELF
+	Log    *[]string // lifecycle calls, in order
+}
+
+func (self *ModelTestReentrantProxy) OnRegister() {
+	*self.Log = append(*self.Log, "onRegister "+self.Name)
+
+	switch self.Action {
+	case REGISTER_OTHER:
+		self.Facade.RegisterProxy(&ModelTestReentrantProxy{Proxy: proxy.Proxy{Name: self.Name + "Other"}, Log: self.Log})
+		if self.Facade.RetrieveProxy(self.Name) == self {
+			*self.Log = append(*self.Log, "retrieved "+self.Name)
+		}
+	case REMOVE_SELF:
+		self.Facade.RemoveProxy(self.Name)
+	}
+
+	*self.Log = append(*self.Log, "onRegister done "+self.Name)
+}
+
+func (self *ModelTestReentrantProxy) OnRemove() {
+	*self.Log = append(*self.Log, "onRemove "+self.Name)
+}
@@ -0,0 +1,35 @@
+//
+//  ModelTestSnapshotProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package model
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+/*
+ModelTestSnapshotState The state of a ModelTestSnapshotProxy.
+*/
+type ModelTestSnapshotState struct {
+	Count int
+	Items []string
+}
+
+/*
+ModelTestSnapshotProxy A Proxy subclass used by ModelTest, whose state is saved in snapshots.
+*/
+type ModelTestSnapshotProxy struct {
+	proxy.Proxy
+	State ModelTestSnapshotState
+}
+
+func (self *ModelTestSnapshotProxy) Snapshot() (interface{}, error) {
+	return self.State, nil
+}
+
+func (self *ModelTestSnapshotProxy) Restore(decode func(target interface{}) error) error {
+	return decode(&self.State)
+}
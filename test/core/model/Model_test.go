@@ -9,10 +9,20 @@
 package model
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/model"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 /*
@@ -137,3 +147,421 @@ func TestOnRegisterAndOnRemove(t *testing.T) {
 		t.Error("Expecting proxy.GetData() == ON_REMOVE_CALLED")
 	}
 }
+
+/*
+Tests that a Proxy may register and retrieve proxies from its onRegister
+*/
+func TestOnRegisterRegistersProxy(t *testing.T) {
+	var f = facade.GetInstance("ModelTestKey6", func() interfaces.IFacade { return &facade.Facade{Key: "ModelTestKey6"} })
+	var m = model.GetInstance("ModelTestKey6", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey6"} })
+
+	var log []string
+	f.RegisterProxy(&ModelTestReentrantProxy{Proxy: proxy.Proxy{Name: "reentrant"}, Action: REGISTER_OTHER, Log: &log})
+
+	var expected = []string{"onRegister reentrant", "onRegister reentrantOther", "onRegister done reentrantOther", "retrieved reentrant", "onRegister done reentrant"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+	if m.HasProxy("reentrantOther") == false {
+		t.Error("Expecting model.HasProxy('reentrantOther') == true")
+	}
+}
+
+/*
+Tests that a Proxy removed during its onRegister has its
+onRemove called once onRegister returns
+*/
+func TestOnRegisterRemovesProxy(t *testing.T) {
+	var f = facade.GetInstance("ModelTestKey7", func() interfaces.IFacade { return &facade.Facade{Key: "ModelTestKey7"} })
+	var m = model.GetInstance("ModelTestKey7", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey7"} })
+
+	var log []string
+	f.RegisterProxy(&ModelTestReentrantProxy{Proxy: proxy.Proxy{Name: "reentrant"}, Action: REMOVE_SELF, Log: &log})
+
+	var expected = []string{"onRegister reentrant", "onRegister done reentrant", "onRemove reentrant"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+	if m.HasProxy("reentrant") == true {
+		t.Error("Expecting model.HasProxy('reentrant') == false")
+	}
+}
+
+/*
+Tests listing, filtering and finding Proxies by type.
+*/
+func TestFindProxies(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey8", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey8"} })
+	var closer1 = &ModelTestCloserProxy{Proxy: proxy.Proxy{Name: "closer1"}}
+	var closer2 = &ModelTestCloserProxy{Proxy: proxy.Proxy{Name: "closer2"}}
+	var plain = &proxy.Proxy{Name: "plain", Data: 42}
+	m.RegisterProxy(closer2)
+	m.RegisterProxy(plain)
+	m.RegisterProxy(closer1)
+
+	if reflect.DeepEqual(m.ListProxies(), []string{"closer1", "closer2", "plain"}) == false {
+		t.Error("Expecting the sorted Proxy names, got", m.ListProxies())
+	}
+	if m.ProxyCount() != 3 {
+		t.Error("Expecting m.ProxyCount() == 3")
+	}
+
+	var withData = m.FindProxies(func(proxy interfaces.IProxy) bool { return proxy.GetData() != nil })
+	if len(withData) != 1 || withData[0] != plain {
+		t.Error("Expecting only the plain Proxy to have data")
+	}
+
+	var closers = m.FindProxiesOfType(reflect.TypeOf((*io.Closer)(nil)).Elem())
+	if len(closers) != 2 || closers[0] != closer1 || closers[1] != closer2 {
+		t.Error("Expecting both closers, sorted by name")
+	}
+
+	var plains = m.FindProxiesOfType(reflect.TypeOf(&proxy.Proxy{}))
+	if len(plains) != 1 || plains[0] != plain {
+		t.Error("Expecting only the plain Proxy to be a *proxy.Proxy")
+	}
+
+	for _, closer := range model.ProxiesOf[io.Closer](m) {
+		closer.Close()
+	}
+	if closer1.Closed == false || closer2.Closed == false {
+		t.Error("Expecting every closer to be closed")
+	}
+}
+
+/*
+Tests saving the ISnapshotProxy states of a Model
+and restoring them into another Core, in both formats.
+*/
+func TestSnapshot(t *testing.T) {
+	for i, format := range []interfaces.SnapshotFormat{interfaces.SNAPSHOT_JSON, interfaces.SNAPSHOT_GOB} {
+		var source = model.GetInstance(fmt.Sprint("ModelTestKey9.", i), func() interfaces.IModel { return &model.Model{Key: fmt.Sprint("ModelTestKey9.", i)} })
+		source.RegisterProxy(&ModelTestSnapshotProxy{Proxy: proxy.Proxy{Name: "cart"}, State: ModelTestSnapshotState{Count: 2, Items: []string{"a", "b"}}})
+		source.RegisterProxy(&proxy.Proxy{Name: "plain", Data: "not saved"})
+
+		var buffer bytes.Buffer
+		if err := source.SaveSnapshot(&buffer, format); err != nil {
+			t.Fatal("Expecting the snapshot to be saved, got", err)
+		}
+
+		var target = model.GetInstance(fmt.Sprint("ModelTestKey10.", i), func() interfaces.IModel { return &model.Model{Key: fmt.Sprint("ModelTestKey10.", i)} })
+		var cart = &ModelTestSnapshotProxy{Proxy: proxy.Proxy{Name: "cart"}}
+		target.RegisterProxy(cart)
+		if err := target.RestoreSnapshot(&buffer, format); err != nil {
+			t.Fatal("Expecting the snapshot to be restored, got", err)
+		}
+
+		if reflect.DeepEqual(cart.State, ModelTestSnapshotState{Count: 2, Items: []string{"a", "b"}}) == false {
+			t.Error("Expecting the state to be restored, got", cart.State)
+		}
+		if target.HasProxy("plain") {
+			t.Error("Expecting no proxy to be created by the restore")
+		}
+	}
+}
+
+/*
+Tests that restoring a state that does not decode names the Proxy.
+*/
+func TestRestoreSnapshotError(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey11", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey11"} })
+	m.RegisterProxy(&ModelTestSnapshotProxy{Proxy: proxy.Proxy{Name: "cart"}})
+
+	var err = m.RestoreSnapshot(strings.NewReader(`{"cart": {"Count": "two"}}`), interfaces.SNAPSHOT_JSON)
+	if err == nil || strings.Contains(err.Error(), `"cart"`) == false {
+		t.Error("Expecting an error naming the proxy, got", err)
+	}
+}
+
+/*
+Tests that a lazy Proxy is constructed and registered
+once, the first time it is retrieved.
+*/
+func TestRegisterLazyProxy(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey12", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey12"} })
+
+	var constructed int32
+	m.RegisterLazyProxy(MODEL_TEST_PROXY, func() interfaces.IProxy {
+		atomic.AddInt32(&constructed, 1)
+		return &ModelTestProxy{Proxy: proxy.Proxy{Name: MODEL_TEST_PROXY}}
+	})
+
+	if m.HasProxy(MODEL_TEST_PROXY) == false {
+		t.Error("Expecting the lazy proxy to be registered")
+	}
+	if len(m.FindProxies(func(proxy interfaces.IProxy) bool { return true })) != 0 || atomic.LoadInt32(&constructed) != 0 {
+		t.Error("Expecting the lazy proxy not to be constructed before it is retrieved")
+	}
+
+	var wg sync.WaitGroup
+	var retrieved = make([]interfaces.IProxy, 8)
+	for i := range retrieved {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			retrieved[i] = m.RetrieveProxy(MODEL_TEST_PROXY)
+		}(i)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&constructed) != 1 {
+		t.Error("Expecting the lazy proxy to be constructed once, got", constructed)
+	}
+	for _, p := range retrieved {
+		if p != retrieved[0] {
+			t.Error("Expecting every retrieval to return the same proxy")
+		}
+	}
+	if retrieved[0].GetData() != ON_REGISTER_CALLED {
+		t.Error("Expecting OnRegister to be called")
+	}
+
+	m.RemoveProxy(MODEL_TEST_PROXY)
+	if retrieved[0].GetData() != ON_REMOVE_CALLED {
+		t.Error("Expecting OnRemove to be called")
+	}
+}
+
+/*
+Tests that a lazy Proxy removed before it is retrieved is never constructed.
+*/
+func TestRemoveLazyProxy(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey13", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey13"} })
+
+	var constructed = false
+	m.RegisterLazyProxy(MODEL_TEST_PROXY, func() interfaces.IProxy {
+		constructed = true
+		return &ModelTestProxy{Proxy: proxy.Proxy{Name: MODEL_TEST_PROXY}}
+	})
+
+	if m.RemoveProxy(MODEL_TEST_PROXY) != nil {
+		t.Error("Expecting no proxy to be returned")
+	}
+	if m.RetrieveProxy(MODEL_TEST_PROXY) != nil || constructed {
+		t.Error("Expecting the removed lazy proxy never to be constructed")
+	}
+}
+
+/*
+Tests that the Proxies registered by RegisterProxies are removed
+again when a later one fails to register.
+*/
+func TestRegisterProxiesRollsBack(t *testing.T) {
+	var f = facade.GetInstance("ModelTestKey20", func() interfaces.IFacade { return &facade.Facade{Key: "ModelTestKey20"} })
+	f.SetDuplicatePolicy(interfaces.DUPLICATE_ERROR)
+
+	var log []string
+	var err = f.RegisterProxies(
+		&ModelTestReentrantProxy{Proxy: proxy.Proxy{Name: "a"}, Action: REGISTER_OTHER, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "aOther"}, Dependencies: []string{"a"}, Log: &log},
+	)
+	if errors.Is(err, model.ErrDuplicateProxy) == false {
+		t.Error("Expecting ErrDuplicateProxy, got", err)
+	}
+	if f.HasProxy("a") {
+		t.Error("Expecting the registered proxy to be removed again")
+	}
+	if f.HasProxy("aOther") == false {
+		t.Error("Expecting the proxy registered by OnRegister to stay")
+	}
+	if len(log) == 0 || log[len(log)-1] != "onRemove a" {
+		t.Error("Expecting OnRemove to be called on the removed proxy, got", log)
+	}
+}
+
+/*
+Tests that a lazy Proxy whose factory panics stays
+lazy, and is constructed by a later retrieval.
+*/
+func TestLazyProxyFactoryPanics(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey18", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey18"} })
+
+	var calls = 0
+	m.RegisterLazyProxy(MODEL_TEST_PROXY, func() interfaces.IProxy {
+		calls++
+		if calls == 1 {
+			panic("factory failed")
+		}
+		return &ModelTestProxy{Proxy: proxy.Proxy{Name: MODEL_TEST_PROXY}}
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expecting the panic of the factory to go on")
+			}
+		}()
+		m.RetrieveProxy(MODEL_TEST_PROXY)
+	}()
+
+	var done = make(chan interfaces.IProxy)
+	go func() { done <- m.RetrieveProxy(MODEL_TEST_PROXY) }()
+	select {
+	case p := <-done:
+		if p == nil || p.GetData() != ON_REGISTER_CALLED {
+			t.Error("Expecting the lazy proxy to be constructed and registered")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expecting the retrieval not to block after the factory panicked")
+	}
+}
+
+/*
+Tests that restoring a snapshot constructs the lazy
+ISnapshotProxy instances it has a state for.
+*/
+func TestRestoreSnapshotLazyProxy(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey19", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey19"} })
+
+	var constructed = map[string]bool{}
+	for _, name := range []string{"cart", "wishlist"} {
+		name := name
+		m.RegisterLazyProxy(name, func() interfaces.IProxy {
+			constructed[name] = true
+			return &ModelTestSnapshotProxy{Proxy: proxy.Proxy{Name: name}}
+		})
+	}
+
+	if err := m.RestoreSnapshot(strings.NewReader(`{"cart": {"Count": 2, "Items": ["a", "b"]}}`), interfaces.SNAPSHOT_JSON); err != nil {
+		t.Fatal("Expecting the snapshot to be restored, got", err)
+	}
+
+	var cart = m.RetrieveProxy("cart").(*ModelTestSnapshotProxy)
+	if reflect.DeepEqual(cart.State, ModelTestSnapshotState{Count: 2, Items: []string{"a", "b"}}) == false {
+		t.Error("Expecting the state of the lazy proxy to be restored, got", cart.State)
+	}
+	if constructed["wishlist"] {
+		t.Error("Expecting a lazy proxy without a state not to be constructed")
+	}
+}
+
+/*
+Tests that Proxies are registered after their dependencies,
+and removed before them when the Model is removed.
+*/
+func TestRegisterProxiesInDependencyOrder(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey14", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey14"} })
+
+	var log []string
+	m.RegisterProxy(&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "config"}, Log: &log})
+	var err = m.RegisterProxies(
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "users"}, Dependencies: []string{"db", "cache"}, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "db"}, Dependencies: []string{"config"}, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "cache"}, Log: &log},
+	)
+	if err != nil {
+		t.Fatal("Expecting the proxies to be registered, got", err)
+	}
+
+	var expected = []string{"register config", "register db", "register cache", "register users"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+
+	log = nil
+	model.RemoveModel("ModelTestKey14")
+	expected = []string{"remove users", "remove cache", "remove db", "remove config"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+}
+
+/*
+Tests that nothing is registered when a dependency
+is missing or the dependencies form a cycle.
+*/
+func TestRegisterProxiesErrors(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey15", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey15"} })
+
+	var log []string
+	var err = m.RegisterProxies(
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "a"}, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "b"}, Dependencies: []string{"missing"}, Log: &log},
+	)
+	if errors.Is(err, model.ErrMissingDependency) == false || strings.Contains(err.Error(), `"missing"`) == false {
+		t.Error("Expecting ErrMissingDependency naming the dependency, got", err)
+	}
+
+	err = m.RegisterProxies(
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "a"}, Dependencies: []string{"b"}, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "b"}, Dependencies: []string{"c"}, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "c"}, Dependencies: []string{"a"}, Log: &log},
+	)
+	if errors.Is(err, model.ErrDependencyCycle) == false || strings.Contains(err.Error(), "a -> b -> c -> a") == false {
+		t.Error("Expecting ErrDependencyCycle naming the cycle, got", err)
+	}
+
+	if len(log) != 0 || m.ProxyCount() != 0 {
+		t.Error("Expecting nothing registered, got", log)
+	}
+}
+
+/*
+Tests that the default policy replaces a Proxy registered
+under the same name, with OnRemove, and that DUPLICATE_IGNORE
+keeps it.
+*/
+func TestDuplicateProxyReplaceAndIgnore(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey16", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey16"} })
+
+	var log []string
+	m.RegisterProxy(&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log})
+	var replacement = &ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log}
+	if err := m.TryRegisterProxy(replacement); err != nil {
+		t.Fatal("Expecting the proxy to be replaced, got", err)
+	}
+
+	var expected = []string{"register first", "remove first", "register first"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+	if m.RetrieveProxy("first") != replacement {
+		t.Error("Expecting the replacement to be registered")
+	}
+
+	log = nil
+	m.SetDuplicatePolicy(interfaces.DUPLICATE_IGNORE)
+	if err := m.TryRegisterProxy(&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log}); err != nil {
+		t.Error("Expecting the duplicate to be ignored, got", err)
+	}
+	if len(log) != 0 || m.RetrieveProxy("first") != replacement {
+		t.Error("Expecting the replacement to stay registered, got", log)
+	}
+}
+
+/*
+Tests that DUPLICATE_ERROR keeps the registered Proxy,
+TryRegisterProxy and RegisterProxies returning an error
+and RegisterProxy panicking.
+*/
+func TestDuplicateProxyError(t *testing.T) {
+	var m = model.GetInstance("ModelTestKey17", func() interfaces.IModel { return &model.Model{Key: "ModelTestKey17"} })
+	m.SetDuplicatePolicy(interfaces.DUPLICATE_ERROR)
+
+	var log []string
+	m.RegisterProxy(&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log})
+
+	var err = m.TryRegisterProxy(&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log})
+	if errors.Is(err, model.ErrDuplicateProxy) == false || strings.Contains(err.Error(), `"first"`) == false {
+		t.Error("Expecting ErrDuplicateProxy naming the proxy, got", err)
+	}
+
+	err = m.RegisterProxies(
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "second"}, Log: &log},
+		&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log},
+	)
+	if errors.Is(err, model.ErrDuplicateProxy) == false || m.HasProxy("second") {
+		t.Error("Expecting ErrDuplicateProxy and nothing registered, got", err)
+	}
+
+	if reflect.DeepEqual(log, []string{"register first"}) == false {
+		t.Error("Expecting the first proxy to stay registered, got", log)
+	}
+
+	defer func() {
+		if recovered, ok := recover().(error); ok == false || errors.Is(recovered, model.ErrDuplicateProxy) == false {
+			t.Error("Expecting RegisterProxy to panic with ErrDuplicateProxy, got", recovered)
+		}
+	}()
+	m.RegisterProxy(&ModelTestDependentProxy{Proxy: proxy.Proxy{Name: "first"}, Log: &log})
+}
@@ -0,0 +1,55 @@
+//
+//  ViewTestReentrantMediator.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package view
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/mediator"
+)
+
+const REGISTER_OTHER = "registerOther"
+const REMOVE_SELF = "removeSelf"
+
+/*
+ViewTestReentrantMediator A Mediator class used by ViewTest,
+that uses the View from its OnRegister.
+*/
+type ViewTestReentrantMediator struct {
+	mediator.Mediator
+	Action string    // REGISTER_OTHER or REMOVE_SELF
+	Log    *[]string // lifecycle calls and notifications, in order
+}
+
+func (self *ViewTestReentrantMediator) ListNotificationInterests() []string {
+	return []string{VIEWTEST_NOTE1}
+}
+
+func (self *ViewTestReentrantMediator) HandleNotification(notification interfaces.INotification) {
+	*self.Log = append(*self.Log, "handle "+self.Name)
+}
+
+func (self *ViewTestReentrantMediator) OnRegister() {
+	*self.Log = append(*self.Log, "onRegister "+self.Name)
+
+	switch self.Action {
+	case REGISTER_OTHER:
+		self.Facade.RegisterMediator(&ViewTestReentrantMediator{Mediator: mediator.Mediator{Name: self.Name + "Other"}, Log: self.Log})
+		if self.Facade.RetrieveMediator(self.Name+"Other") != nil {
+			*self.Log = append(*self.Log, "retrieved "+self.Name+"Other")
+		}
+	case REMOVE_SELF:
+		self.Facade.RemoveMediator(self.Name)
+	}
+
+	*self.Log = append(*self.Log, "onRegister done "+self.Name)
+}
+
+func (self *ViewTestReentrantMediator) OnRemove() {
+	*self.Log = append(*self.Log, "onRemove "+self.Name)
+}
@@ -9,10 +9,13 @@
 package view
 
 import (
+	"errors"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/mediator"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"reflect"
 	"testing"
 )
 
@@ -415,3 +418,178 @@ func TestModifyObserverListDuringNotification(t *testing.T) {
 		t.Error("Expecting counter == 0")
 	}
 }
+
+/*
+Tests that removing one of several observers of a
+notification leaves each of the others notified once.
+*/
+func TestRemoveObserverLeavesOthersNotifiedOnce(t *testing.T) {
+	var v = view.GetInstance("ViewTestKey12", func() interfaces.IView { return &view.View{Key: "ViewTestKey12"} })
+
+	var counts = map[string]int{}
+	for _, name := range []string{"first", "second", "third"} {
+		var name = name
+		v.RegisterObserver(ViewTestNote_NAME, &observer.Observer{Notify: func(notification interfaces.INotification) { counts[name]++ }, Context: name})
+	}
+
+	v.RemoveObserver(ViewTestNote_NAME, "first")
+	v.NotifyObservers(ViewTestNoteNew(nil))
+
+	if counts["first"] != 0 || counts["second"] != 1 || counts["third"] != 1 {
+		t.Error("Expecting second and third notified once, got", counts)
+	}
+}
+
+/*
+Tests that a Mediator may register and retrieve mediators from its onRegister
+*/
+func TestOnRegisterRegistersMediator(t *testing.T) {
+	var f = facade.GetInstance("ViewTestKey13", func() interfaces.IFacade { return &facade.Facade{Key: "ViewTestKey13"} })
+	var v = view.GetInstance("ViewTestKey13", func() interfaces.IView { return &view.View{Key: "ViewTestKey13"} })
+
+	var log []string
+	f.RegisterMediator(&ViewTestReentrantMediator{Mediator: mediator.Mediator{Name: "reentrant"}, Action: REGISTER_OTHER, Log: &log})
+	v.NotifyObservers(observer.NewNotification(VIEWTEST_NOTE1, nil, ""))
+
+	var expected = []string{"onRegister reentrant", "onRegister reentrantOther", "onRegister done reentrantOther", "retrieved reentrantOther",
+		"onRegister done reentrant", "handle reentrant", "handle reentrantOther"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+}
+
+/*
+Tests that a Mediator removed during its onRegister has its
+observers removed and its onRemove called once onRegister returns
+*/
+func TestOnRegisterRemovesMediator(t *testing.T) {
+	var f = facade.GetInstance("ViewTestKey14", func() interfaces.IFacade { return &facade.Facade{Key: "ViewTestKey14"} })
+	var v = view.GetInstance("ViewTestKey14", func() interfaces.IView { return &view.View{Key: "ViewTestKey14"} })
+
+	var log []string
+	f.RegisterMediator(&ViewTestReentrantMediator{Mediator: mediator.Mediator{Name: "reentrant"}, Action: REMOVE_SELF, Log: &log})
+	v.NotifyObservers(observer.NewNotification(VIEWTEST_NOTE1, nil, ""))
+
+	var expected = []string{"onRegister reentrant", "onRegister done reentrant", "onRemove reentrant"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+	if v.HasMediator("reentrant") == true {
+		t.Error("Expecting view.HasMediator('reentrant') == false")
+	}
+}
+
+/*
+Tests listing the registered Mediators, their interests and the Observers.
+*/
+func TestIntrospection(t *testing.T) {
+	var v = view.GetInstance("ViewTestKey15", func() interfaces.IView { return &view.View{Key: "ViewTestKey15"} })
+
+	var data = Data{}
+	var m = &ViewTestMediator2{Mediator: mediator.Mediator{Name: ViewTestMediator2_NAME, ViewComponent: &data}}
+	v.RegisterMediator(m)
+	v.RegisterMediator(&ViewTestMediator{Mediator: mediator.Mediator{Name: ViewTestMediator_NAME}})
+	v.RegisterObserver(VIEWTEST_NOTE1, &observer.Observer{Notify: func(notification interfaces.INotification) {}, Context: t})
+
+	if reflect.DeepEqual(v.ListMediators(), []string{ViewTestMediator_NAME, ViewTestMediator2_NAME}) == false {
+		t.Error("Expecting both Mediators listed, got", v.ListMediators())
+	}
+	if v.MediatorCount() != 2 {
+		t.Error("Expecting v.MediatorCount() == 2")
+	}
+	if reflect.DeepEqual(v.ListMediatorInterests(ViewTestMediator2_NAME), []string{VIEWTEST_NOTE1, VIEWTEST_NOTE2}) == false {
+		t.Error("Expecting the interests of ViewTestMediator2, got", v.ListMediatorInterests(ViewTestMediator2_NAME))
+	}
+	if v.ListMediatorInterests("missing") != nil {
+		t.Error("Expecting no interests for a missing Mediator")
+	}
+	if contains(v.ListNotifications(), VIEWTEST_NOTE2) == false {
+		t.Error("Expecting VIEWTEST_NOTE2 to be observed, got", v.ListNotifications())
+	}
+
+	var observers = v.ListObservers(VIEWTEST_NOTE1)
+	if len(observers) != 2 || v.ObserverCount(VIEWTEST_NOTE1) != 2 {
+		t.Fatal("Expecting 2 observers of VIEWTEST_NOTE1")
+	}
+	if observers[0].CompareNotifyContext(m) == false || observers[1].CompareNotifyContext(t) == false {
+		t.Error("Expecting the observers in the order they were registered")
+	}
+
+	v.RemoveMediator(ViewTestMediator2_NAME)
+	if v.MediatorCount() != 1 || v.ObserverCount(VIEWTEST_NOTE2) != 0 {
+		t.Error("Expecting the Mediator and its Observers to be gone")
+	}
+	if contains(v.ListNotifications(), VIEWTEST_NOTE2) == true {
+		t.Error("Expecting VIEWTEST_NOTE2 not to be observed, got", v.ListNotifications())
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Tests that DUPLICATE_REPLACE removes the registered Mediator,
+with OnRemove and its Observers, before registering the new one.
+*/
+func TestDuplicateMediatorReplace(t *testing.T) {
+	var v = view.GetInstance("ViewTestKey16", func() interfaces.IView { return &view.View{Key: "ViewTestKey16"} })
+	v.SetDuplicatePolicy(interfaces.DUPLICATE_REPLACE)
+
+	var first, second = Data{}, Data{}
+	v.RegisterMediator(&ViewTestMediator4{Mediator: mediator.Mediator{Name: ViewTestMediator4_NAME, ViewComponent: &first}})
+	var m = &ViewTestMediator4{Mediator: mediator.Mediator{Name: ViewTestMediator4_NAME, ViewComponent: &second}}
+	if err := v.TryRegisterMediator(m); err != nil {
+		t.Fatal("Expecting the Mediator to be replaced, got", err)
+	}
+
+	if first.onRemoveCalled == false || second.onRegisterCalled == false {
+		t.Error("Expecting OnRemove of the first Mediator and OnRegister of the second")
+	}
+	if v.RetrieveMediator(ViewTestMediator4_NAME) != m {
+		t.Error("Expecting the second Mediator to be registered")
+	}
+
+	var data = Data{}
+	v.RegisterMediator(&ViewTestMediator5{Mediator: mediator.Mediator{Name: ViewTestMediator5_NAME, ViewComponent: &data}})
+	v.RegisterMediator(&ViewTestMediator5{Mediator: mediator.Mediator{Name: ViewTestMediator5_NAME, ViewComponent: &data}})
+	v.NotifyObservers(observer.NewNotification(VIEWTEST_NOTE5, "", ""))
+	if data.counter != 1 {
+		t.Error("Expecting only the Observer of the second Mediator, counter == 1, got", data.counter)
+	}
+}
+
+/*
+Tests that DUPLICATE_ERROR keeps the registered Mediator,
+TryRegisterMediator returning an error and RegisterMediator panicking.
+*/
+func TestDuplicateMediatorError(t *testing.T) {
+	var v = view.GetInstance("ViewTestKey17", func() interfaces.IView { return &view.View{Key: "ViewTestKey17"} })
+	v.SetDuplicatePolicy(interfaces.DUPLICATE_ERROR)
+
+	var first, second = Data{}, Data{}
+	var m = &ViewTestMediator4{Mediator: mediator.Mediator{Name: ViewTestMediator4_NAME, ViewComponent: &first}}
+	if err := v.TryRegisterMediator(m); err != nil {
+		t.Fatal("Expecting the first registration to succeed, got", err)
+	}
+
+	var err = v.TryRegisterMediator(&ViewTestMediator4{Mediator: mediator.Mediator{Name: ViewTestMediator4_NAME, ViewComponent: &second}})
+	if errors.Is(err, view.ErrDuplicateMediator) == false {
+		t.Error("Expecting ErrDuplicateMediator, got", err)
+	}
+	if first.onRemoveCalled == true || second.onRegisterCalled == true || v.RetrieveMediator(ViewTestMediator4_NAME) != m {
+		t.Error("Expecting the first Mediator to stay registered")
+	}
+
+	defer func() {
+		if recovered, ok := recover().(error); ok == false || errors.Is(recovered, view.ErrDuplicateMediator) == false {
+			t.Error("Expecting RegisterMediator to panic with ErrDuplicateMediator, got", recovered)
+		}
+	}()
+	v.RegisterMediator(&ViewTestMediator4{Mediator: mediator.Mediator{Name: ViewTestMediator4_NAME, ViewComponent: &second}})
+}
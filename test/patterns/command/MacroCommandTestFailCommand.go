@@ -0,0 +1,43 @@
+//
+//  MacroCommandTestFailCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+var ErrMacroCommandTest = errors.New("macro command test failure")
+
+/*
+MacroCommandTestFailCommand A MacroCommand subclass used by MacroCommandTest,
+whose second SubCommand fails before the third one runs.
+*/
+type MacroCommandTestFailCommand struct {
+	command.MacroCommand
+}
+
+func (self *MacroCommandTestFailCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &MacroCommandTestSub1Command{} })
+	self.AddSubCommand(func() interfaces.ICommand { return &MacroCommandTestFailSubCommand{} })
+	self.AddSubCommand(func() interfaces.ICommand { return &MacroCommandTestSub2Command{} })
+	self.MacroCommand.Execute(notification)
+}
+
+/*
+MacroCommandTestFailSubCommand A SimpleCommand subclass used by MacroCommandTest, that always fails.
+*/
+type MacroCommandTestFailSubCommand struct {
+	command.SimpleCommand
+}
+
+func (self *MacroCommandTestFailSubCommand) Execute(notification interfaces.INotification) {
+	self.Fail(ErrMacroCommandTest)
+}
@@ -0,0 +1,45 @@
+//
+//  MacroCommandTestIdempotentCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+MacroCommandTestIdempotentCommand A MacroCommand subclass used by MacroCommandTest,
+whose second SubCommand is idempotent.
+*/
+type MacroCommandTestIdempotentCommand struct {
+	command.MacroCommand
+}
+
+func (self *MacroCommandTestIdempotentCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &MacroCommandTestSub1Command{} })
+	self.AddSubCommand(func() interfaces.ICommand { return &MacroCommandTestIdempotentSubCommand{} })
+	self.MacroCommand.Execute(notification)
+}
+
+/*
+MacroCommandTestIdempotentSubCommand A SimpleCommand subclass used by MacroCommandTest,
+that adds the input to Result2 once per key.
+*/
+type MacroCommandTestIdempotentSubCommand struct {
+	command.SimpleCommand
+}
+
+func (self *MacroCommandTestIdempotentSubCommand) IdempotencyKey(notification interfaces.INotification) string {
+	return "macro-idempotent"
+}
+
+func (self *MacroCommandTestIdempotentSubCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*MacroCommandTestVO)
+	vo.Result2 += vo.Input
+}
@@ -0,0 +1,42 @@
+//
+//  MacroCommandTestInjectedCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+)
+
+/*
+MacroCommandTestInjectedCommand A MacroCommand subclass used by MacroCommandTest,
+whose SubCommand has its dependencies injected.
+*/
+type MacroCommandTestInjectedCommand struct {
+	command.MacroCommand
+}
+
+func (self *MacroCommandTestInjectedCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &MacroCommandTestInjectedSubCommand{} })
+	self.MacroCommand.Execute(notification)
+}
+
+/*
+MacroCommandTestInjectedSubCommand A SimpleCommand subclass used by MacroCommandTest,
+multiplying the input by the data of an injected Proxy.
+*/
+type MacroCommandTestInjectedSubCommand struct {
+	command.SimpleCommand
+	Proxy *proxy.Proxy `inject:"proxy:MacroCommandTestProxy"`
+}
+
+func (self *MacroCommandTestInjectedSubCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*MacroCommandTestVO)
+	vo.Result1 = vo.Input * self.Proxy.GetData().(int)
+}
@@ -9,11 +9,14 @@
 package command
 
 import (
+	"errors"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/model"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
 	"testing"
 )
 
@@ -87,3 +90,84 @@ func TestMacroCommandExecuteViaControllerView(t *testing.T) {
 		t.Error("Expecting vo.Result2 == 25")
 	}
 }
+
+/*
+Tests that a failing SubCommand stops the MacroCommand,
+which then fails with the same error.
+*/
+func TestMacroCommandSubCommandFails(t *testing.T) {
+	var vo = MacroCommandTestVO{Input: 5}
+	var note = observer.NewNotification("MacroCommandTest", &vo, "")
+
+	var mc = MacroCommandTestFailCommand{}
+	mc.Notifier.InitializeNotifier("MacroCommandTest3")
+	mc.Execute(note)
+
+	if vo.Result1 != 10 {
+		t.Error("Expecting vo.Result1 == 10")
+	}
+	if vo.Result2 != 0 {
+		t.Error("Expecting vo.Result2 == 0")
+	}
+	if mc.Err() != ErrMacroCommandTest {
+		t.Error("Expecting mc.Err() == ErrMacroCommandTest")
+	}
+}
+
+/*
+Tests that a duplicate idempotent SubCommand is skipped
+while the other SubCommands execute.
+*/
+func TestMacroCommandIdempotentSubCommand(t *testing.T) {
+	var c = controller.GetInstance("MacroCommandTest4", func() interfaces.IController { return &controller.Controller{Key: "MacroCommandTest4"} })
+	c.RegisterCommand("MacroCommandTest", func() interfaces.ICommand { return &MacroCommandTestIdempotentCommand{} })
+
+	var duplicates = 0
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) {
+		if errors.Is(err, controller.ErrDuplicate) {
+			duplicates++
+		}
+	})
+
+	var vo = MacroCommandTestVO{Input: 5}
+	c.ExecuteCommand(observer.NewNotification("MacroCommandTest", &vo, ""))
+	vo.Result1 = 0
+	c.ExecuteCommand(observer.NewNotification("MacroCommandTest", &vo, ""))
+
+	if vo.Result1 != 10 {
+		t.Error("Expecting the other SubCommand to execute again, vo.Result1 == 10")
+	}
+	if vo.Result2 != 5 {
+		t.Error("Expecting the idempotent SubCommand to execute once, vo.Result2 == 5, got", vo.Result2)
+	}
+	if duplicates != 1 {
+		t.Error("Expecting one duplicate reported, got", duplicates)
+	}
+}
+
+/*
+Tests that the SubCommands of a MacroCommand have their
+dependencies injected by the injector of the Controller,
+and that the MacroCommand fails if they are missing.
+*/
+func TestMacroCommandInjectsSubCommands(t *testing.T) {
+	var m = model.GetInstance("MacroCommandTest5", func() interfaces.IModel { return &model.Model{Key: "MacroCommandTest5"} })
+	var c = controller.GetInstance("MacroCommandTest5", func() interfaces.IController { return &controller.Controller{Key: "MacroCommandTest5"} })
+	c.(*controller.Controller).SetInjector(&controller.Injector{Key: "MacroCommandTest5"})
+
+	var errs []error
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { errs = append(errs, err) })
+	c.RegisterCommand("MacroCommandTest", func() interfaces.ICommand { return &MacroCommandTestInjectedCommand{} })
+
+	var vo = MacroCommandTestVO{Input: 5}
+	c.ExecuteCommand(observer.NewNotification("MacroCommandTest", &vo, ""))
+	if len(errs) != 1 || errors.Is(errs[0], controller.ErrMissingDependency) == false {
+		t.Error("Expecting the missing dependency to fail the MacroCommand, got", errs)
+	}
+
+	m.RegisterProxy(&proxy.Proxy{Name: "MacroCommandTestProxy", Data: 3})
+	c.ExecuteCommand(observer.NewNotification("MacroCommandTest", &vo, ""))
+	if vo.Result1 != 15 {
+		t.Error("Expecting the injected proxy to be used, vo.Result1 == 15, got", vo.Result1)
+	}
+}
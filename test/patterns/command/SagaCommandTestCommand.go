@@ -0,0 +1,29 @@
+//
+//  SagaCommandTestCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+SagaCommandTestCommand A SagaCommand subclass used by SagaCommandTest,
+executing three SagaCommandTestStepCommands.
+*/
+type SagaCommandTestCommand struct {
+	command.SagaCommand
+}
+
+func (self *SagaCommandTestCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand { return &SagaCommandTestStepCommand{Step: 0} })
+	self.AddSubCommand(func() interfaces.ICommand { return &SagaCommandTestStepCommand{Step: 1} })
+	self.AddSubCommand(func() interfaces.ICommand { return &SagaCommandTestStepCommand{Step: 2} })
+	self.SagaCommand.Execute(notification)
+}
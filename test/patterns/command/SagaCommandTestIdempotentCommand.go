@@ -0,0 +1,45 @@
+//
+//  SagaCommandTestIdempotentCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+SagaCommandTestIdempotentCommand A SagaCommand subclass used by SagaCommandTest,
+executing two SagaCommandTestIdempotentStepCommands.
+*/
+type SagaCommandTestIdempotentCommand struct {
+	command.SagaCommand
+}
+
+func (self *SagaCommandTestIdempotentCommand) Execute(notification interfaces.INotification) {
+	self.AddSubCommand(func() interfaces.ICommand {
+		return &SagaCommandTestIdempotentStepCommand{SagaCommandTestStepCommand{Step: 0}}
+	})
+	self.AddSubCommand(func() interfaces.ICommand {
+		return &SagaCommandTestIdempotentStepCommand{SagaCommandTestStepCommand{Step: 1}}
+	})
+	self.SagaCommand.Execute(notification)
+}
+
+/*
+SagaCommandTestIdempotentStepCommand A SagaCommandTestStepCommand
+executed once per step.
+*/
+type SagaCommandTestIdempotentStepCommand struct {
+	SagaCommandTestStepCommand
+}
+
+func (self *SagaCommandTestIdempotentStepCommand) IdempotencyKey(notification interfaces.INotification) string {
+	return fmt.Sprint("saga-step-", self.Step)
+}
@@ -0,0 +1,43 @@
+//
+//  SagaCommandTestStepCommand.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+)
+
+/*
+SagaCommandTestStepCommand An ICompensableCommand used by SagaCommandTest.
+*/
+type SagaCommandTestStepCommand struct {
+	command.SimpleCommand
+	Step int
+}
+
+/*
+Execute Log the step, or fail if it is the step the VO asks to fail.
+*/
+func (self *SagaCommandTestStepCommand) Execute(notification interfaces.INotification) {
+	var vo = notification.Body().(*SagaCommandTestVO)
+	if vo.FailAt == self.Step {
+		panic(errors.New(fmt.Sprint("step ", self.Step, " failed")))
+	}
+	vo.Log = append(vo.Log, fmt.Sprint("execute ", self.Step))
+}
+
+/*
+Compensate Log the compensation of the step.
+*/
+func (self *SagaCommandTestStepCommand) Compensate(notification interfaces.INotification) {
+	var vo = notification.Body().(*SagaCommandTestVO)
+	vo.Log = append(vo.Log, fmt.Sprint("compensate ", self.Step))
+}
@@ -0,0 +1,17 @@
+//
+//  SagaCommandTestVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+/*
+SagaCommandTestVO A utility class used by SagaCommandTest.
+*/
+type SagaCommandTestVO struct {
+	FailAt int      // index of the step that should fail, -1 for none
+	Log    []string // steps executed and compensated, in order
+}
@@ -0,0 +1,140 @@
+//
+//  SagaCommand_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package command
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/command"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"reflect"
+	"testing"
+)
+
+/*
+Test the PureMVC SagaCommand class.
+*/
+
+/*
+Tests that a SagaCommand whose steps all complete is committed.
+*/
+func TestSagaCommandCommitted(t *testing.T) {
+	var f = facade.GetInstance("SagaCommandTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "SagaCommandTestKey1"} })
+	var v = view.GetInstance("SagaCommandTestKey1", func() interfaces.IView { return &view.View{Key: "SagaCommandTestKey1"} })
+	f.RegisterCommand("SagaCommandTest", func() interfaces.ICommand { return &SagaCommandTestCommand{} })
+
+	var result interfaces.INotification
+	v.RegisterObserver(command.SAGA_RESULT, &observer.Observer{Notify: func(notification interfaces.INotification) { result = notification }, Context: t})
+
+	var vo = &SagaCommandTestVO{FailAt: -1}
+	f.SendNotification("SagaCommandTest", vo, "")
+
+	if reflect.DeepEqual(vo.Log, []string{"execute 0", "execute 1", "execute 2"}) == false {
+		t.Error("Expecting every step executed, got", vo.Log)
+	}
+	if result == nil {
+		t.Fatal("Expecting a SAGA_RESULT notification")
+	}
+	if result.Type() != command.SAGA_COMMITTED {
+		t.Error("Expecting result.Type() == SAGA_COMMITTED")
+	}
+	var body = result.Body().(*command.SagaResult)
+	if body.Committed == false || body.FailedStep != -1 || body.Err != nil {
+		t.Error("Expecting a committed result")
+	}
+}
+
+/*
+Tests that when a step fails the completed steps are
+compensated in reverse order and the saga is rolled back.
+*/
+func TestSagaCommandRolledBack(t *testing.T) {
+	var f = facade.GetInstance("SagaCommandTestKey2", func() interfaces.IFacade { return &facade.Facade{Key: "SagaCommandTestKey2"} })
+	var v = view.GetInstance("SagaCommandTestKey2", func() interfaces.IView { return &view.View{Key: "SagaCommandTestKey2"} })
+	f.RegisterCommand("SagaCommandTest", func() interfaces.ICommand { return &SagaCommandTestCommand{} })
+
+	var result interfaces.INotification
+	v.RegisterObserver(command.SAGA_RESULT, &observer.Observer{Notify: func(notification interfaces.INotification) { result = notification }, Context: t})
+
+	var vo = &SagaCommandTestVO{FailAt: 2}
+	f.SendNotification("SagaCommandTest", vo, "")
+
+	var expected = []string{"execute 0", "execute 1", "compensate 1", "compensate 0"}
+	if reflect.DeepEqual(vo.Log, expected) == false {
+		t.Error("Expecting", expected, "got", vo.Log)
+	}
+	if result == nil {
+		t.Fatal("Expecting a SAGA_RESULT notification")
+	}
+	if result.Type() != command.SAGA_ROLLED_BACK {
+		t.Error("Expecting result.Type() == SAGA_ROLLED_BACK")
+	}
+	var body = result.Body().(*command.SagaResult)
+	if body.Committed == true {
+		t.Error("Expecting body.Committed == false")
+	}
+	if body.FailedStep != 2 {
+		t.Error("Expecting body.FailedStep == 2")
+	}
+	if body.FailedCommand.(*SagaCommandTestStepCommand).Step != 2 {
+		t.Error("Expecting body.FailedCommand to be step 2")
+	}
+	if body.Err == nil || body.Err.Error() != "step 2 failed" {
+		t.Error("Expecting body.Err == 'step 2 failed'")
+	}
+	if body.Compensated != 2 {
+		t.Error("Expecting body.Compensated == 2")
+	}
+}
+
+/*
+Tests that when the first step fails nothing is compensated.
+*/
+func TestSagaCommandFirstStepFails(t *testing.T) {
+	var f = facade.GetInstance("SagaCommandTestKey3", func() interfaces.IFacade { return &facade.Facade{Key: "SagaCommandTestKey3"} })
+	f.RegisterCommand("SagaCommandTest", func() interfaces.ICommand { return &SagaCommandTestCommand{} })
+
+	var vo = &SagaCommandTestVO{FailAt: 0}
+	f.SendNotification("SagaCommandTest", vo, "")
+
+	if len(vo.Log) != 0 {
+		t.Error("Expecting nothing executed or compensated, got", vo.Log)
+	}
+}
+
+/*
+Tests that the compensated steps of a rolled back SagaCommand
+release their idempotency keys, so a redelivery executes them again.
+*/
+func TestSagaCommandRedeliveredAfterRollback(t *testing.T) {
+	var f = facade.GetInstance("SagaCommandTestKey4", func() interfaces.IFacade { return &facade.Facade{Key: "SagaCommandTestKey4"} })
+	var v = view.GetInstance("SagaCommandTestKey4", func() interfaces.IView { return &view.View{Key: "SagaCommandTestKey4"} })
+	f.RegisterCommand("SagaCommandTest", func() interfaces.ICommand { return &SagaCommandTestIdempotentCommand{} })
+
+	var result interfaces.INotification
+	v.RegisterObserver(command.SAGA_RESULT, &observer.Observer{Notify: func(notification interfaces.INotification) { result = notification }, Context: t})
+
+	var vo = &SagaCommandTestVO{FailAt: 1}
+	f.SendNotification("SagaCommandTest", vo, "")
+	if result == nil || result.Type() != command.SAGA_ROLLED_BACK {
+		t.Fatal("Expecting the first delivery rolled back")
+	}
+
+	vo.FailAt = -1
+	vo.Log = nil
+	f.SendNotification("SagaCommandTest", vo, "")
+
+	if reflect.DeepEqual(vo.Log, []string{"execute 0", "execute 1"}) == false {
+		t.Error("Expecting every step executed again, got", vo.Log)
+	}
+	if result.Type() != command.SAGA_COMMITTED {
+		t.Error("Expecting result.Type() == SAGA_COMMITTED")
+	}
+}
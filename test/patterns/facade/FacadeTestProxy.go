@@ -0,0 +1,18 @@
+//
+//  FacadeTestProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package facade
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+/*
+FacadeTestProxy A Proxy subclass used by FacadeTest.
+*/
+type FacadeTestProxy struct {
+	proxy.Proxy
+}
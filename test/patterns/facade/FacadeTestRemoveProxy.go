@@ -0,0 +1,26 @@
+//
+//  FacadeTestRemoveProxy.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package facade
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+/*
+FacadeTestRemoveProxy A Proxy subclass used by FacadeTest,
+sending a notification when it is removed.
+*/
+type FacadeTestRemoveProxy struct {
+	proxy.Proxy
+}
+
+/*
+OnRemove Send FacadeRemoveTest with the FacadeTestVO held by the Proxy.
+*/
+func (self *FacadeTestRemoveProxy) OnRemove() {
+	self.SendNotification("FacadeRemoveTest", self.Data, "")
+}
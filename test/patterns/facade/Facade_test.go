@@ -9,11 +9,16 @@
 package facade
 
 import (
+	"context"
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/controller"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/mediator"
 	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"strings"
 	"testing"
+	"time"
 )
 
 /*
@@ -271,3 +276,133 @@ func TestHasCoreAndRemoveCore(t *testing.T) {
 		t.Error("Expecting facade.HasCore('FacadeTestKey11') == false")
 	}
 }
+
+/*
+Tests that removing a Core lets the OnRemove of its Proxies
+send a notification handled by a Command.
+*/
+func TestRemoveCoreNotifiesFromOnRemove(t *testing.T) {
+	var f = facade.GetInstance("FacadeTestKey15", func() interfaces.IFacade { return &facade.Facade{Key: "FacadeTestKey15"} })
+	f.RegisterCommand("FacadeRemoveTest", func() interfaces.ICommand { return &FacadeTestCommand{} })
+
+	var vo = &FacadeTestVO{Input: 16}
+	f.RegisterProxy(&FacadeTestRemoveProxy{Proxy: proxy.Proxy{Name: "FacadeTestRemoveProxy", Data: vo}})
+
+	var done = make(chan struct{})
+	go func() {
+		facade.RemoveCore("FacadeTestKey15")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expecting RemoveCore not to deadlock when OnRemove sends a notification")
+	}
+
+	if vo.Result != 32 {
+		t.Error("Expecting vo.Result == 32, got", vo.Result)
+	}
+	if facade.HasCore("FacadeTestKey15") != false {
+		t.Error("Expecting facade.HasCore('FacadeTestKey15') == false")
+	}
+}
+
+/*
+Tests that a CommandFunc handles notifications like
+an ICommand and can be removed by name.
+*/
+func TestRegisterCommandFunc(t *testing.T) {
+	var f = facade.GetInstance("FacadeTestKey12", func() interfaces.IFacade { return &facade.Facade{Key: "FacadeTestKey12"} })
+
+	var received interfaces.IFacade
+	f.RegisterCommandFunc("FacadeFuncTest", func(ctx context.Context, notification interfaces.INotification, facade interfaces.IFacade) error {
+		var vo = notification.Body().(*FacadeTestVO)
+		vo.Result = 2 * vo.Input
+		received = facade
+		return nil
+	})
+
+	var vo = FacadeTestVO{Input: 21}
+	f.SendNotification("FacadeFuncTest", &vo, "")
+
+	if vo.Result != 42 {
+		t.Error("Expecting vo.Result == 42")
+	}
+	if received != f {
+		t.Error("Expecting the CommandFunc to receive the Facade of its Core")
+	}
+
+	f.RemoveCommand("FacadeFuncTest")
+	if f.HasCommand("FacadeFuncTest") != false {
+		t.Error("Expecting f.HasCommand('FacadeFuncTest') == false")
+	}
+
+	vo.Result = 0
+	f.SendNotification("FacadeFuncTest", &vo, "")
+	if vo.Result != 0 {
+		t.Error("Expecting the removed CommandFunc not to be called")
+	}
+}
+
+/*
+Tests that the error returned by a CommandFunc is reported.
+*/
+func TestRegisterCommandFuncError(t *testing.T) {
+	var f = facade.GetInstance("FacadeTestKey13", func() interfaces.IFacade { return &facade.Facade{Key: "FacadeTestKey13"} })
+	var c = controller.GetInstance("FacadeTestKey13", func() interfaces.IController { return &controller.Controller{Key: "FacadeTestKey13"} })
+
+	var commandError *controller.CommandError
+	c.(*controller.Controller).SetErrorHandler(func(err *controller.CommandError) { commandError = err })
+
+	var failure = errors.New("func failed")
+	f.RegisterCommandFunc("FacadeFuncTest", func(ctx context.Context, notification interfaces.INotification, facade interfaces.IFacade) error {
+		return failure
+	})
+	f.SendNotification("FacadeFuncTest", nil, "")
+
+	if commandError == nil || errors.Is(commandError, failure) == false {
+		t.Error("Expecting the returned error to be reported, got", commandError)
+	}
+	if _, ok := commandError.Command.(*facade.FuncCommand); ok == false {
+		t.Error("Expecting the failing command to be a FuncCommand")
+	}
+}
+
+/*
+Tests retrieving a Proxy as its type, and the errors
+when it is missing or of another type.
+*/
+func TestRetrieveProxyAs(t *testing.T) {
+	var f = facade.GetInstance("FacadeTestKey14", func() interfaces.IFacade { return &facade.Facade{Key: "FacadeTestKey14"} })
+	var p = &FacadeTestProxy{Proxy: proxy.Proxy{Name: "typed"}}
+	f.RegisterProxy(p)
+	f.RegisterProxy(&proxy.Proxy{Name: "untyped"})
+
+	typed, err := facade.RetrieveProxyAs[*FacadeTestProxy](f, "typed")
+	if err != nil || typed != p {
+		t.Error("Expecting the FacadeTestProxy, got", typed, err)
+	}
+
+	_, err = facade.RetrieveProxyAs[*FacadeTestProxy](f, "missing")
+	if errors.Is(err, facade.ErrProxyNotFound) == false || strings.Contains(err.Error(), `"missing"`) == false {
+		t.Error("Expecting ErrProxyNotFound naming the Proxy, got", err)
+	}
+
+	_, err = facade.RetrieveProxyAs[*FacadeTestProxy](f, "untyped")
+	if errors.Is(err, facade.ErrProxyType) == false || strings.Contains(err.Error(), "*facade.FacadeTestProxy") == false {
+		t.Error("Expecting ErrProxyType naming the types, got", err)
+	}
+
+	if facade.MustRetrieve[*FacadeTestProxy](f, "typed") != p {
+		t.Error("Expecting MustRetrieve to return the FacadeTestProxy")
+	}
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		facade.MustRetrieve[*FacadeTestProxy](f, "untyped")
+	}()
+	if err, ok := recovered.(error); ok == false || errors.Is(err, facade.ErrProxyType) == false {
+		t.Error("Expecting MustRetrieve to panic with ErrProxyType, got", recovered)
+	}
+}
@@ -0,0 +1,224 @@
+//
+//  CachingProxy_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Test the PureMVC CachingProxy class.
+*/
+
+/*
+newCachingProxy Create a CachingProxy loading upper case keys, logging its metrics.
+*/
+func newCachingProxy(name string, log *[]string) *proxy.CachingProxy[string, string] {
+	return &proxy.CachingProxy[string, string]{
+		BaseProxy: proxy.BaseProxy{Name: name},
+		Loader: func(ctx context.Context, key string) (string, error) {
+			*log = append(*log, "load "+key)
+			return strings.ToUpper(key), nil
+		},
+		OnHit:  func(key string) { *log = append(*log, "hit "+key) },
+		OnMiss: func(key string) { *log = append(*log, "miss "+key) },
+		OnEvict: func(key string, reason proxy.EvictReason) {
+			*log = append(*log, fmt.Sprint("evict ", key, " ", reason))
+		},
+	}
+}
+
+/*
+Tests hits, misses and the eviction of the least recently used value.
+*/
+func TestCachingProxyLeastRecentlyUsed(t *testing.T) {
+	var log []string
+	var p = newCachingProxy("letters", &log)
+	p.MaxSize = 2
+
+	for _, key := range []string{"a", "b", "a", "c", "a"} {
+		if value, err := p.Get(context.Background(), key); err != nil || value != strings.ToUpper(key) {
+			t.Error("Expecting", strings.ToUpper(key), "got", value, err)
+		}
+	}
+
+	var expected = []string{"miss a", "load a", "miss b", "load b", "hit a", "miss c", "load c", "evict b capacity", "hit a"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+	if reflect.DeepEqual(p.GetData(), map[string]string{"a": "A", "c": "C"}) == false {
+		t.Error("Expecting a and c cached, got", p.GetData())
+	}
+}
+
+/*
+Tests that values expire once their TTL has passed,
+and that failed loads are not cached.
+*/
+func TestCachingProxyExpiryAndErrors(t *testing.T) {
+	var log []string
+	var p = newCachingProxy("expiring", &log)
+	p.TTL = 10 * time.Millisecond
+
+	p.Get(context.Background(), "a")
+	time.Sleep(20 * time.Millisecond)
+	p.Get(context.Background(), "a")
+
+	var expected = []string{"miss a", "load a", "evict a expired", "miss a", "load a"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+
+	var errOffline = errors.New("offline")
+	p.Loader = func(ctx context.Context, key string) (string, error) { return "", errOffline }
+	if _, err := p.Get(context.Background(), "b"); err != errOffline {
+		t.Error("Expecting the error of the loader, got", err)
+	}
+	if p.Len() != 1 {
+		t.Error("Expecting the failed load not to be cached")
+	}
+}
+
+/*
+Tests that the InvalidateNotifications of a registered
+CachingProxy invalidate its values.
+*/
+func TestCachingProxyInvalidateNotifications(t *testing.T) {
+	var f = facade.GetInstance("CachingProxyTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "CachingProxyTestKey1"} })
+
+	var log []string
+	var p = newCachingProxy("prices", &log)
+	p.InvalidateNotifications = []string{"PricesChanged"}
+	f.RegisterProxy(p)
+	p.SetData(map[string]string{"a": "A", "b": "B", "c": "C"})
+
+	log = nil
+	f.SendNotification("PricesChanged", "a", "")
+	f.SendNotification("PricesChanged", []string{"b"}, "")
+	if reflect.DeepEqual(log, []string{"evict a invalidated", "evict b invalidated"}) == false {
+		t.Error("Expecting a and b invalidated, got", log)
+	}
+
+	f.SendNotification("PricesChanged", nil, "")
+	if p.Len() != 0 {
+		t.Error("Expecting every value invalidated")
+	}
+
+	f.RemoveProxy("prices")
+	p.Put("d", "D")
+	f.SendNotification("PricesChanged", nil, "")
+	if p.Len() != 1 {
+		t.Error("Expecting the removed proxy to stop observing the notifications")
+	}
+}
+
+/*
+Tests that a shared load goes on while a Get waits for it, even
+if the Get that started it stopped, and is cancelled once no Get waits.
+*/
+func TestCachingProxyLoadOutlivesCaller(t *testing.T) {
+	var started = make(chan bool, 2)
+	var release = map[string]chan bool{"a": make(chan bool), "b": make(chan bool)}
+	var cancelled = make(chan bool, 1)
+	var p = &proxy.CachingProxy[string, string]{
+		BaseProxy: proxy.BaseProxy{Name: "shared"},
+		Loader: func(ctx context.Context, key string) (string, error) {
+			started <- true
+			select {
+			case <-release[key]:
+				return strings.ToUpper(key), nil
+			case <-ctx.Done():
+				cancelled <- true
+				return "", ctx.Err()
+			}
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var first = make(chan error)
+	go func() {
+		_, err := p.Get(ctx, "a")
+		first <- err
+	}()
+	<-started
+
+	var second = make(chan string)
+	go func() {
+		value, _ := p.Get(context.Background(), "a")
+		second <- value
+	}()
+	time.Sleep(20 * time.Millisecond) // let the second Get wait for the load
+
+	cancel()
+	if err := <-first; errors.Is(err, context.Canceled) == false {
+		t.Error("Expecting the first Get to stop with context.Canceled, got", err)
+	}
+	close(release["a"])
+	if value := <-second; value != "A" {
+		t.Error("Expecting the load to go on for the second Get, got", value)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := p.Get(ctx, "b"); errors.Is(err, context.Canceled) == false {
+		t.Error("Expecting context.Canceled, got", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expecting the load to be cancelled once no Get waits for it")
+	}
+	if p.Len() != 1 {
+		t.Error("Expecting only the completed load to be cached, got", p.GetData())
+	}
+}
+
+/*
+Tests that a value put while its key is loading is not overwritten by the load.
+*/
+func TestCachingProxyPutDuringLoad(t *testing.T) {
+	var started = make(chan bool, 1)
+	var release = make(chan bool)
+	var p = &proxy.CachingProxy[string, string]{
+		BaseProxy: proxy.BaseProxy{Name: "put"},
+		Loader: func(ctx context.Context, key string) (string, error) {
+			started <- true
+			<-release
+			return "loaded", nil
+		},
+	}
+
+	var loaded = make(chan string)
+	go func() {
+		value, _ := p.Get(context.Background(), "a")
+		loaded <- value
+	}()
+	<-started
+	p.Put("a", "put")
+	close(release)
+
+	if value := <-loaded; value != "loaded" {
+		t.Error("Expecting the Get to return the loaded value, got", value)
+	}
+	if value, _ := p.Get(context.Background(), "a"); value != "put" {
+		t.Error("Expecting the put value to stay cached, got", value)
+	}
+}
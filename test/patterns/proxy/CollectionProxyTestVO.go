@@ -0,0 +1,18 @@
+//
+//  CollectionProxyTestVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+CollectionProxyTestVO A record used by CollectionProxyTest.
+*/
+type CollectionProxyTestVO struct {
+	ID   int
+	Name string
+	Team string
+}
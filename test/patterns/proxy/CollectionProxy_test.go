@@ -0,0 +1,207 @@
+//
+//  CollectionProxy_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"reflect"
+	"testing"
+)
+
+/*
+Test the PureMVC CollectionProxy class.
+*/
+
+func newCollectionProxy() *proxy.CollectionProxy[int, CollectionProxyTestVO] {
+	return &proxy.CollectionProxy[int, CollectionProxyTestVO]{
+		BaseProxy: proxy.BaseProxy{Name: "users"},
+		KeyOf:     func(item CollectionProxyTestVO) int { return item.ID },
+	}
+}
+
+func itemNames(items []CollectionProxyTestVO) []string {
+	var result = []string{}
+	for _, item := range items {
+		result = append(result, item.Name)
+	}
+	return result
+}
+
+/*
+Tests adding, updating, retrieving and removing items.
+*/
+func TestCollectionProxyCrud(t *testing.T) {
+	var p = newCollectionProxy()
+
+	if err := p.Add(CollectionProxyTestVO{ID: 1, Name: "ada"}); err != nil {
+		t.Fatal("Expecting the item to be added, got", err)
+	}
+	if err := p.Add(CollectionProxyTestVO{ID: 1, Name: "bob"}); errors.Is(err, proxy.ErrItemExists) == false {
+		t.Error("Expecting ErrItemExists, got", err)
+	}
+	if err := p.Update(CollectionProxyTestVO{ID: 2, Name: "bob"}); errors.Is(err, proxy.ErrItemNotFound) == false {
+		t.Error("Expecting ErrItemNotFound, got", err)
+	}
+
+	p.Put(CollectionProxyTestVO{ID: 2, Name: "bob"})
+	if err := p.Update(CollectionProxyTestVO{ID: 1, Name: "ada lovelace"}); err != nil {
+		t.Error("Expecting the item to be updated, got", err)
+	}
+
+	if item, ok := p.Get(1); ok == false || item.Name != "ada lovelace" {
+		t.Error("Expecting the updated item, got", item)
+	}
+	if reflect.DeepEqual(itemNames(p.All()), []string{"ada lovelace", "bob"}) == false {
+		t.Error("Expecting the items in the order they were added in, got", itemNames(p.All()))
+	}
+
+	if item, ok := p.Remove(1); ok == false || item.Name != "ada lovelace" {
+		t.Error("Expecting the removed item, got", item)
+	}
+	if _, ok := p.Remove(1); ok == true {
+		t.Error("Expecting nothing to remove")
+	}
+	if p.Has(1) || p.Len() != 1 {
+		t.Error("Expecting one item left")
+	}
+}
+
+/*
+Tests that secondary indexes follow the changes of the items.
+*/
+func TestCollectionProxyIndexes(t *testing.T) {
+	var p = newCollectionProxy()
+	p.Add(CollectionProxyTestVO{ID: 1, Name: "ada", Team: "red"})
+	p.AddIndex("team", func(item CollectionProxyTestVO) interface{} { return item.Team })
+	p.Add(CollectionProxyTestVO{ID: 2, Name: "bob", Team: "blue"})
+	p.Add(CollectionProxyTestVO{ID: 3, Name: "cy", Team: "red"})
+
+	found, err := p.FindBy("team", "red")
+	if err != nil || reflect.DeepEqual(itemNames(found), []string{"ada", "cy"}) == false {
+		t.Error("Expecting ada and cy in the red team, got", itemNames(found), err)
+	}
+
+	p.Update(CollectionProxyTestVO{ID: 1, Name: "ada", Team: "blue"})
+	p.Remove(3)
+	if found, _ = p.FindBy("team", "red"); len(found) != 0 {
+		t.Error("Expecting nobody in the red team, got", itemNames(found))
+	}
+	if found, _ = p.FindBy("team", "blue"); reflect.DeepEqual(itemNames(found), []string{"ada", "bob"}) == false {
+		t.Error("Expecting ada and bob in the blue team, got", itemNames(found))
+	}
+
+	if _, err = p.FindBy("missing", "red"); errors.Is(err, proxy.ErrUnknownIndex) == false {
+		t.Error("Expecting ErrUnknownIndex, got", err)
+	}
+}
+
+/*
+Tests filtering, sorting and paginating the items.
+*/
+func TestCollectionProxyQuery(t *testing.T) {
+	var p = newCollectionProxy()
+	p.SetData([]CollectionProxyTestVO{
+		{ID: 1, Name: "eve", Team: "red"},
+		{ID: 2, Name: "bob", Team: "blue"},
+		{ID: 3, Name: "dan", Team: "red"},
+		{ID: 4, Name: "ada", Team: "red"},
+		{ID: 5, Name: "cy", Team: "red"},
+	})
+
+	var page = p.Query(proxy.CollectionQuery[CollectionProxyTestVO]{
+		Filter: func(item CollectionProxyTestVO) bool { return item.Team == "red" },
+		Less:   func(a CollectionProxyTestVO, b CollectionProxyTestVO) bool { return a.Name < b.Name },
+		Offset: 1,
+		Limit:  2,
+	})
+	if page.Total != 4 || reflect.DeepEqual(itemNames(page.Items), []string{"cy", "dan"}) == false {
+		t.Error("Expecting cy and dan of 4 red items, got", itemNames(page.Items), page.Total)
+	}
+
+	page = p.Query(proxy.CollectionQuery[CollectionProxyTestVO]{Offset: 10})
+	if page.Total != 5 || len(page.Items) != 0 {
+		t.Error("Expecting an empty page past the end, got", itemNames(page.Items))
+	}
+
+	page = p.Query(proxy.CollectionQuery[CollectionProxyTestVO]{Offset: -1, Limit: -1})
+	if page.Total != 5 || len(page.Items) != 5 {
+		t.Error("Expecting a negative offset and limit to return every item, got", itemNames(page.Items))
+	}
+}
+
+/*
+Tests that changes of the items of a registered CollectionProxy
+send the added, updated and removed notifications.
+*/
+func TestCollectionProxyNotifications(t *testing.T) {
+	var f = facade.GetInstance("CollectionProxyTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "CollectionProxyTestKey1"} })
+	var v = view.GetInstance("CollectionProxyTestKey1", func() interfaces.IView { return &view.View{Key: "CollectionProxyTestKey1"} })
+
+	var log []string
+	var observe = func(notification interfaces.INotification) {
+		var change = notification.Body().(*proxy.ItemChange[int, CollectionProxyTestVO])
+		log = append(log, notification.Name()+" "+change.Old.Name+">"+change.New.Name)
+	}
+	for _, name := range []string{"UserAdded", "UserUpdated", "UserRemoved"} {
+		v.RegisterObserver(name, &observer.Observer{Notify: observe, Context: t})
+	}
+
+	var p = newCollectionProxy()
+	p.AddedNotification, p.UpdatedNotification, p.RemovedNotification = "UserAdded", "UserUpdated", "UserRemoved"
+	p.Add(CollectionProxyTestVO{ID: 1, Name: "ada"})
+	f.RegisterProxy(p)
+
+	p.Add(CollectionProxyTestVO{ID: 2, Name: "bob"})
+	p.Put(CollectionProxyTestVO{ID: 2, Name: "rob"})
+	p.Replace([]CollectionProxyTestVO{{ID: 2, Name: "bo"}, {ID: 3, Name: "cy"}})
+
+	var expected = []string{"UserAdded >bob", "UserUpdated bob>rob", "UserRemoved ada>", "UserUpdated rob>bo", "UserAdded >cy"}
+	if reflect.DeepEqual(log, expected) == false {
+		t.Error("Expecting", expected, "got", log)
+	}
+}
+
+/*
+Tests that an index extractor that panics leaves the
+CollectionProxy unlocked and unchanged.
+*/
+func TestCollectionProxyExtractorPanic(t *testing.T) {
+	var p = newCollectionProxy()
+	p.Add(CollectionProxyTestVO{ID: 1, Name: "ada", Team: "core"})
+	p.AddIndex("team", func(item CollectionProxyTestVO) interface{} {
+		if item.Team == "" {
+			panic("no team")
+		}
+		return item.Team
+	})
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		p.Put(CollectionProxyTestVO{ID: 2, Name: "bob"})
+	}()
+	if recovered == nil {
+		t.Fatal("Expecting Put to panic with the extractor")
+	}
+
+	if p.Len() != 1 {
+		t.Error("Expecting the collection to be unchanged, got", p.Len())
+	}
+	if err := p.Add(CollectionProxyTestVO{ID: 2, Name: "bob", Team: "core"}); err != nil {
+		t.Error("Expecting the collection to accept changes again, got", err)
+	}
+	if found, _ := p.FindBy("team", "core"); len(found) != 2 {
+		t.Error("Expecting both items indexed, got", itemNames(found))
+	}
+}
@@ -0,0 +1,27 @@
+//
+//  ObservableProxyTestVO.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+/*
+ObservableProxyTestVO A utility class used by ObservableProxyTest.
+*/
+type ObservableProxyTestVO struct {
+	Name    string
+	Address *ObservableProxyTestAddress
+	Tags    map[string]string
+	Scores  []int
+}
+
+/*
+ObservableProxyTestAddress A utility class used by ObservableProxyTest.
+*/
+type ObservableProxyTestAddress struct {
+	City   string
+	Street string
+}
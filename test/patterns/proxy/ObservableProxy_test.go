@@ -0,0 +1,100 @@
+//
+//  ObservableProxy_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"reflect"
+	"testing"
+)
+
+/*
+Test the PureMVC ObservableProxy class.
+*/
+
+/*
+Tests that an Update in place sends a Diff of the changed fields,
+including fields of nested pointers, maps and slices.
+*/
+func TestObservableProxyUpdate(t *testing.T) {
+	var f = facade.GetInstance("ObservableProxyTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "ObservableProxyTestKey1"} })
+	var v = view.GetInstance("ObservableProxyTestKey1", func() interfaces.IView { return &view.View{Key: "ObservableProxyTestKey1"} })
+
+	var diffs []*proxy.Diff
+	v.RegisterObserver("UserChanged", &observer.Observer{Notify: func(notification interfaces.INotification) {
+		diffs = append(diffs, notification.Body().(*proxy.Diff))
+	}, Context: t})
+
+	var p = &proxy.ObservableProxy[ObservableProxyTestVO]{BaseProxy: proxy.BaseProxy{Name: "user"}, ChangeNotification: "UserChanged", Data: ObservableProxyTestVO{
+		Name:    "Ada",
+		Address: &ObservableProxyTestAddress{City: "London", Street: "Baker"},
+		Tags:    map[string]string{"home": "a", "work": "b"},
+		Scores:  []int{1, 2},
+	}}
+	f.RegisterProxy(p)
+
+	p.Update(func(data *ObservableProxyTestVO) {
+		data.Address.City = "Paris"
+		data.Tags["home"] = "c"
+		delete(data.Tags, "work")
+		data.Tags["gym"] = "d"
+		data.Scores[0] = 5
+	})
+
+	if len(diffs) != 1 {
+		t.Fatal("Expecting one change notification, got", len(diffs))
+	}
+	var expected = []proxy.FieldChange{
+		{Path: "Address.City", Old: "London", New: "Paris"},
+		{Path: "Tags[gym]", New: "d"},
+		{Path: "Tags[home]", Old: "a", New: "c"},
+		{Path: "Tags[work]", Old: "b"},
+		{Path: "Scores", Old: []int{1, 2}, New: []int{5, 2}},
+	}
+	if reflect.DeepEqual(diffs[0].Changes, expected) == false {
+		t.Error("Expecting", expected, "got", diffs[0].Changes)
+	}
+	if diffs[0].Changed("Address") == false || diffs[0].Changed("Name") == true {
+		t.Error("Expecting Address, and not Name, to have changed")
+	}
+
+	p.Update(func(data *ObservableProxyTestVO) {})
+	if len(diffs) != 1 {
+		t.Error("Expecting no notification when nothing changed")
+	}
+}
+
+/*
+Tests that Set sends a Diff, and a notification per changed field.
+*/
+func TestObservableProxyFieldNotifications(t *testing.T) {
+	var f = facade.GetInstance("ObservableProxyTestKey2", func() interfaces.IFacade { return &facade.Facade{Key: "ObservableProxyTestKey2"} })
+	var v = view.GetInstance("ObservableProxyTestKey2", func() interfaces.IView { return &view.View{Key: "ObservableProxyTestKey2"} })
+
+	var names []string
+	var record = &observer.Observer{Notify: func(notification interfaces.INotification) { names = append(names, notification.Name()) }, Context: t}
+	v.RegisterObserver("UserChanged", record)
+	v.RegisterObserver("UserChanged.Name", record)
+	v.RegisterObserver("UserChanged.Address.City", record)
+
+	var p = &proxy.ObservableProxy[ObservableProxyTestVO]{BaseProxy: proxy.BaseProxy{Name: "user"}, ChangeNotification: "UserChanged", FieldNotifications: true}
+	f.RegisterProxy(p)
+	p.Set(ObservableProxyTestVO{Name: "Grace"})
+
+	if reflect.DeepEqual(names, []string{"UserChanged", "UserChanged.Name"}) == false {
+		t.Error("Expecting UserChanged and UserChanged.Name, got", names)
+	}
+	if p.Get().Name != "Grace" {
+		t.Error("Expecting p.Get().Name == 'Grace'")
+	}
+}
@@ -0,0 +1,264 @@
+//
+//  RemoteProxy_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+Test the PureMVC RemoteProxy class.
+*/
+
+/*
+Tests that a loaded RemoteProxy is not loaded again unless refreshed,
+that a failed load keeps the data, and the state notifications.
+*/
+func TestRemoteProxyLoadAndRefresh(t *testing.T) {
+	var f = facade.GetInstance("RemoteProxyTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "RemoteProxyTestKey1"} })
+	var v = view.GetInstance("RemoteProxyTestKey1", func() interfaces.IView { return &view.View{Key: "RemoteProxyTestKey1"} })
+
+	var states []string
+	v.RegisterObserver("UsersState", &observer.Observer{Notify: func(notification interfaces.INotification) {
+		var change = notification.Body().(*proxy.LoadStateChange)
+		states = append(states, change.Old.String()+">"+change.New.String())
+	}, Context: t})
+
+	var calls = 0
+	var errOffline = errors.New("offline")
+	var p = &proxy.RemoteProxy[int]{BaseProxy: proxy.BaseProxy{Name: "users"}, StateNotification: "UsersState", Fetch: func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 3 {
+			return 0, errOffline
+		}
+		return calls * 10, nil
+	}}
+	f.RegisterProxy(p)
+
+	if p.State() != proxy.LOAD_IDLE {
+		t.Error("Expecting LOAD_IDLE before the first load")
+	}
+	if data, err := p.Load(context.Background()); data != 10 || err != nil {
+		t.Error("Expecting 10, got", data, err)
+	}
+	if data, _ := p.Load(context.Background()); data != 10 || calls != 1 {
+		t.Error("Expecting the loaded data without fetching again, got", data, calls)
+	}
+	if data, _ := p.Refresh(context.Background()); data != 20 || calls != 2 {
+		t.Error("Expecting the refresh to fetch again, got", data, calls)
+	}
+
+	if _, err := p.Refresh(context.Background()); err != errOffline {
+		t.Error("Expecting the error of the fetch, got", err)
+	}
+	if p.State() != proxy.LOAD_FAILED || p.Err() != errOffline || p.Get() != 20 {
+		t.Error("Expecting LOAD_FAILED keeping the data of the last successful load")
+	}
+
+	var expected = []string{"idle>loading", "loading>loaded", "loaded>loading", "loading>loaded", "loaded>loading", "loading>failed"}
+	if reflect.DeepEqual(states, expected) == false {
+		t.Error("Expecting", expected, "got", states)
+	}
+}
+
+/*
+Tests that concurrent loads share the load in progress.
+*/
+func TestRemoteProxyConcurrentLoads(t *testing.T) {
+	var calls int32
+	var started = make(chan bool, 5)
+	var release = make(chan bool)
+	var p = &proxy.RemoteProxy[string]{BaseProxy: proxy.BaseProxy{Name: "settings"}, Fetch: func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		started <- true
+		<-release
+		return "loaded", nil
+	}}
+
+	var wg sync.WaitGroup
+	var results = make([]string, 5)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _ = p.Load(context.Background())
+	}()
+	<-started
+
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				results[i], _ = p.Refresh(context.Background())
+			} else {
+				results[i], _ = p.Load(context.Background())
+			}
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond) // let the other loads wait for the first one
+	close(release)
+	wg.Wait()
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Error("Expecting a single fetch, got", calls)
+	}
+	for _, result := range results {
+		if result != "loaded" {
+			t.Error("Expecting every load to share the result, got", results)
+			break
+		}
+	}
+}
+
+/*
+Tests that Cancel and removing the RemoteProxy cancel the load.
+*/
+func TestRemoteProxyCancel(t *testing.T) {
+	var f = facade.GetInstance("RemoteProxyTestKey2", func() interfaces.IFacade { return &facade.Facade{Key: "RemoteProxyTestKey2"} })
+
+	var started = make(chan bool, 1)
+	var p = &proxy.RemoteProxy[string]{BaseProxy: proxy.BaseProxy{Name: "report"}, Fetch: func(ctx context.Context) (string, error) {
+		started <- true
+		<-ctx.Done()
+		return "", ctx.Err()
+	}}
+
+	go func() {
+		<-started
+		p.Cancel()
+	}()
+	if _, err := p.Load(context.Background()); errors.Is(err, context.Canceled) == false {
+		t.Error("Expecting context.Canceled, got", err)
+	}
+	if p.State() != proxy.LOAD_FAILED || errors.Is(p.Err(), context.Canceled) == false {
+		t.Error("Expecting the cancelled load to fail")
+	}
+
+	f.RegisterProxy(p)
+	go func() {
+		<-started
+		f.RemoveProxy("report")
+	}()
+	if _, err := p.Refresh(context.Background()); errors.Is(err, context.Canceled) == false {
+		t.Error("Expecting the removal to cancel the load, got", err)
+	}
+}
+
+/*
+Tests that the context.Context of the call starting a load
+only stops that call waiting, while the load goes on for the others.
+*/
+func TestRemoteProxyCallerContext(t *testing.T) {
+	var started = make(chan bool, 1)
+	var release = make(chan bool)
+	var p = &proxy.RemoteProxy[string]{BaseProxy: proxy.BaseProxy{Name: "report"}, Fetch: func(ctx context.Context) (string, error) {
+		started <- true
+		select {
+		case <-release:
+			return "loaded", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := p.Load(ctx); errors.Is(err, context.Canceled) == false {
+		t.Error("Expecting the call to stop waiting with context.Canceled, got", err)
+	}
+	if p.State() != proxy.LOAD_LOADING {
+		t.Error("Expecting the load to go on, got", p.State())
+	}
+
+	close(release)
+	if data, err := p.Load(context.Background()); data != "loaded" || err != nil {
+		t.Error("Expecting the load to complete, got", data, err)
+	}
+}
+
+/*
+Tests that a panicking Fetch fails the load with ErrFetchPanicked.
+*/
+func TestRemoteProxyFetchPanics(t *testing.T) {
+	var p = &proxy.RemoteProxy[string]{BaseProxy: proxy.BaseProxy{Name: "report"}, Fetch: func(ctx context.Context) (string, error) {
+		panic("unreachable")
+	}}
+
+	if _, err := p.Load(context.Background()); errors.Is(err, proxy.ErrFetchPanicked) == false {
+		t.Error("Expecting ErrFetchPanicked, got", err)
+	}
+	if p.State() != proxy.LOAD_FAILED {
+		t.Error("Expecting LOAD_FAILED, got", p.State())
+	}
+}
+
+/*
+Tests that SetData during a load overtakes it: the load does
+not overwrite the data nor send a state notification.
+*/
+func TestRemoteProxySetDataOvertakesLoad(t *testing.T) {
+	var f = facade.GetInstance("RemoteProxyTestKey3", func() interfaces.IFacade { return &facade.Facade{Key: "RemoteProxyTestKey3"} })
+	var v = view.GetInstance("RemoteProxyTestKey3", func() interfaces.IView { return &view.View{Key: "RemoteProxyTestKey3"} })
+
+	var mutex sync.Mutex
+	var states []proxy.LoadState
+	v.RegisterObserver("ReportState", &observer.Observer{Notify: func(notification interfaces.INotification) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		states = append(states, notification.Body().(*proxy.LoadStateChange).New)
+	}, Context: t})
+
+	var started = make(chan bool, 1)
+	var release = make(chan bool)
+	var p = &proxy.RemoteProxy[string]{BaseProxy: proxy.BaseProxy{Name: "report"}, StateNotification: "ReportState", Fetch: func(ctx context.Context) (string, error) {
+		started <- true
+		<-release
+		return "fetched", nil
+	}}
+	f.RegisterProxy(p)
+
+	var result = make(chan string, 1)
+	go func() {
+		data, _ := p.Load(context.Background())
+		result <- data
+	}()
+	<-started
+	p.SetData("set")
+	close(release)
+
+	if data := <-result; data != "fetched" {
+		t.Error("Expecting the waiting call to get the result of its load, got", data)
+	}
+	if data, err := p.Load(context.Background()); data != "set" || err != nil {
+		t.Error("Expecting the data set during the load to be kept, got", data, err)
+	}
+	if p.State() != proxy.LOAD_LOADED {
+		t.Error("Expecting LOAD_LOADED, got", p.State())
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if reflect.DeepEqual(states, []proxy.LoadState{proxy.LOAD_LOADING, proxy.LOAD_LOADED}) == false {
+		t.Error("Expecting no state notification from the overtaken load, got", states)
+	}
+}
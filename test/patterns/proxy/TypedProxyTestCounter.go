@@ -0,0 +1,27 @@
+//
+//  TypedProxyTestCounter.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import "github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+
+/*
+TypedProxyTestCounter A TypedProxy subclass used by TypedProxyTest,
+overriding OnRegister without calling the TypedProxy version.
+*/
+type TypedProxyTestCounter struct {
+	proxy.TypedProxy[int]
+	Registered bool // whether OnRegister was called
+}
+
+/*
+OnRegister Record that the proxy is registered.
+*/
+func (self *TypedProxyTestCounter) OnRegister() {
+	self.Registered = true
+}
@@ -0,0 +1,108 @@
+//
+//  TypedProxy_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"testing"
+)
+
+/*
+Test the PureMVC TypedProxy class.
+*/
+
+/*
+Tests the typed accessors and the IProxy accessors.
+*/
+func TestTypedProxyAccessors(t *testing.T) {
+	var p = &proxy.TypedProxy[[]string]{BaseProxy: proxy.BaseProxy{Name: "colors"}}
+	p.Set([]string{"red", "green"})
+
+	if len(p.Get()) != 2 || p.Get()[0] != "red" {
+		t.Error("Expecting p.Get() == [red green]")
+	}
+
+	var i interfaces.IProxy = p
+	i.SetData([]string{"blue"})
+	if i.GetData().([]string)[0] != "blue" {
+		t.Error("Expecting i.GetData() == [blue]")
+	}
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		i.SetData(42)
+	}()
+	if recovered == nil {
+		t.Error("Expecting SetData to panic with data of another type")
+	}
+}
+
+/*
+Tests that setting the data of a registered TypedProxy
+sends its change notification with the old and new data.
+*/
+func TestTypedProxyChangeNotification(t *testing.T) {
+	var f = facade.GetInstance("TypedProxyTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "TypedProxyTestKey1"} })
+	var v = view.GetInstance("TypedProxyTestKey1", func() interfaces.IView { return &view.View{Key: "TypedProxyTestKey1"} })
+
+	var changes []interfaces.INotification
+	v.RegisterObserver("CounterChanged", &observer.Observer{Notify: func(notification interfaces.INotification) {
+		changes = append(changes, notification)
+	}, Context: t})
+
+	var p = &proxy.TypedProxy[int]{BaseProxy: proxy.BaseProxy{Name: "counter"}, Data: 1, ChangeNotification: "CounterChanged"}
+	p.Set(2)
+	if len(changes) != 0 {
+		t.Error("Expecting no notification before the proxy is registered")
+	}
+
+	f.RegisterProxy(p)
+	p.Set(3)
+
+	if len(changes) != 1 {
+		t.Fatal("Expecting one change notification, got", len(changes))
+	}
+	if changes[0].Type() != "counter" {
+		t.Error("Expecting the notification type to be the proxy name")
+	}
+	var change = changes[0].Body().(*proxy.DataChange[int])
+	if change.Old != 2 || change.New != 3 || change.ProxyName != "counter" {
+		t.Error("Expecting a change from 2 to 3, got", change)
+	}
+}
+
+/*
+Tests that a TypedProxy subclass overriding OnRegister
+still sends its change notification once registered.
+*/
+func TestTypedProxyOverriddenOnRegister(t *testing.T) {
+	var f = facade.GetInstance("TypedProxyTestKey2", func() interfaces.IFacade { return &facade.Facade{Key: "TypedProxyTestKey2"} })
+	var v = view.GetInstance("TypedProxyTestKey2", func() interfaces.IView { return &view.View{Key: "TypedProxyTestKey2"} })
+
+	var changes = 0
+	v.RegisterObserver("CounterChanged", &observer.Observer{Notify: func(notification interfaces.INotification) {
+		changes++
+	}, Context: t})
+
+	var p = &TypedProxyTestCounter{TypedProxy: proxy.TypedProxy[int]{BaseProxy: proxy.BaseProxy{Name: "counter"}, ChangeNotification: "CounterChanged"}}
+	f.RegisterProxy(p)
+	p.Set(1)
+
+	if p.Registered == false {
+		t.Error("Expecting the overriding OnRegister to be called")
+	}
+	if changes != 1 {
+		t.Error("Expecting one change notification, got", changes)
+	}
+}
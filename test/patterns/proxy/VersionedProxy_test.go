@@ -0,0 +1,132 @@
+//
+//  VersionedProxy_test.go
+//  PureMVC Go Multicore
+//
+//  Copyright(c) 2019 Saad Shams <saad.shams@puremvc.org>
+//  Your reuse is governed by the Creative Commons Attribution 3.0 License
+//
+
+package proxy
+
+import (
+	"errors"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/core/view"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/interfaces"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/facade"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/observer"
+	"github.com/puremvc/puremvc-go-multicore-framework/src/patterns/proxy"
+	"sync"
+	"testing"
+)
+
+/*
+Test the PureMVC VersionedProxy class.
+*/
+
+/*
+Tests that CompareAndSwap fails once the version moved.
+*/
+func TestVersionedProxyCompareAndSwap(t *testing.T) {
+	var p = proxy.NewVersionedProxy("stock", 10)
+
+	count, version := p.Read()
+	if count != 10 || version != 0 {
+		t.Error("Expecting 10 at version 0, got", count, version)
+	}
+
+	if next, err := p.CompareAndSwap(version, count-1); err != nil || next != 1 {
+		t.Error("Expecting the swap to succeed at version 1, got", next, err)
+	}
+
+	current, err := p.CompareAndSwap(version, count-2)
+	if errors.Is(err, proxy.ErrVersionConflict) == false || current != 1 {
+		t.Error("Expecting ErrVersionConflict at version 1, got", current, err)
+	}
+	if count, _ = p.Read(); count != 9 {
+		t.Error("Expecting the stale swap to be rejected, count == 9, got", count)
+	}
+
+	if p.Set(100) != 2 || p.Version() != 2 {
+		t.Error("Expecting Set to move the version to 2")
+	}
+}
+
+/*
+Tests that concurrent Updates do not lose each other's changes,
+and that an error of the modify func aborts the Update.
+*/
+func TestVersionedProxyUpdate(t *testing.T) {
+	var p = &proxy.VersionedProxy[int]{BaseProxy: proxy.BaseProxy{Name: "counter"}, MaxRetries: 1000}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := p.Update(func(data int) (int, error) { return data + 1, nil }); err != nil {
+				t.Error("Expecting the update to succeed, got", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count, version := p.Read()
+	if count != 50 || version != 50 {
+		t.Error("Expecting 50 at version 50, got", count, version)
+	}
+
+	var errAbort = errors.New("abort")
+	if _, err := p.Update(func(data int) (int, error) { return 0, errAbort }); err != errAbort {
+		t.Error("Expecting the error of the modify func, got", err)
+	}
+	if p.Version() != 50 {
+		t.Error("Expecting the aborted update to keep version 50")
+	}
+}
+
+/*
+Tests that Update gives up with ErrVersionConflict
+once its retries are exhausted.
+*/
+func TestVersionedProxyUpdateRetriesExhausted(t *testing.T) {
+	var p = &proxy.VersionedProxy[int]{BaseProxy: proxy.BaseProxy{Name: "contended"}, MaxRetries: 2}
+
+	var calls = 0
+	_, err := p.Update(func(data int) (int, error) {
+		calls++
+		p.Set(data + 10) // a concurrent change on every attempt
+		return data + 1, nil
+	})
+	if errors.Is(err, proxy.ErrVersionConflict) == false {
+		t.Error("Expecting ErrVersionConflict, got", err)
+	}
+	if calls != 3 {
+		t.Error("Expecting the first attempt and 2 retries, got", calls)
+	}
+}
+
+/*
+Tests that changes of a registered VersionedProxy send
+its change notification with the new version.
+*/
+func TestVersionedProxyChangeNotification(t *testing.T) {
+	var f = facade.GetInstance("VersionedProxyTestKey1", func() interfaces.IFacade { return &facade.Facade{Key: "VersionedProxyTestKey1"} })
+	var v = view.GetInstance("VersionedProxyTestKey1", func() interfaces.IView { return &view.View{Key: "VersionedProxyTestKey1"} })
+
+	var changes []*proxy.DataChange[string]
+	v.RegisterObserver("StatusChanged", &observer.Observer{Notify: func(notification interfaces.INotification) {
+		changes = append(changes, notification.Body().(*proxy.DataChange[string]))
+	}, Context: t})
+
+	var p = proxy.NewVersionedProxy("status", "idle")
+	p.ChangeNotification = "StatusChanged"
+	f.RegisterProxy(p)
+	p.Update(func(data string) (string, error) { return "busy", nil })
+
+	if len(changes) != 1 {
+		t.Fatal("Expecting one change notification, got", len(changes))
+	}
+	if changes[0].Old != "idle" || changes[0].New != "busy" || changes[0].Version != 1 {
+		t.Error("Expecting a change from idle to busy at version 1, got", changes[0])
+	}
+}